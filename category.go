@@ -0,0 +1,74 @@
+package errmgt
+
+import (
+	"errors"
+	"sync"
+)
+
+// Category is a coarse grouping of error types
+type Category int
+
+const (
+	// CategoryUnknown represents error types without a category
+	CategoryUnknown Category = iota
+	// CategoryClient represents errors caused by the caller
+	CategoryClient
+	// CategoryServer represents errors caused by the system or its dependencies
+	CategoryServer
+)
+
+// String returns the string representation of Category
+func (c Category) String() string {
+	switch c {
+	case CategoryClient:
+		return "client"
+	case CategoryServer:
+		return "server"
+	default:
+		return "unknown"
+	}
+}
+
+var (
+	categoriesMu sync.RWMutex
+	categories   = map[ErrorType]Category{
+		ValidationError: CategoryClient,
+		NotFoundError:   CategoryClient,
+		PermissionError: CategoryClient,
+		InternalError:   CategoryServer,
+		ExternalError:   CategoryServer,
+	}
+)
+
+// SetCategory assigns a category to an error type, for example a custom
+// ErrorType defined by an application
+func SetCategory(errorType ErrorType, category Category) {
+	categoriesMu.Lock()
+	defer categoriesMu.Unlock()
+	categories[errorType] = category
+}
+
+// Category returns the category of the error type
+func (et ErrorType) Category() Category {
+	categoriesMu.RLock()
+	defer categoriesMu.RUnlock()
+	return categories[et]
+}
+
+// IsClientError checks if err is a ManagedError in the client category
+func IsClientError(err error) bool {
+	return hasCategory(err, CategoryClient)
+}
+
+// IsServerError checks if err is a ManagedError in the server category
+func IsServerError(err error) bool {
+	return hasCategory(err, CategoryServer)
+}
+
+func hasCategory(err error, category Category) bool {
+	var managedErr *ManagedError
+	if errors.As(err, &managedErr) && managedErr != nil {
+		return managedErr.Type.Category() == category
+	}
+	return false
+}
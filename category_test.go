@@ -0,0 +1,97 @@
+package errmgt
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorType_Category(t *testing.T) {
+	tests := []struct {
+		errorType ErrorType
+		expected  Category
+	}{
+		{ValidationError, CategoryClient},
+		{NotFoundError, CategoryClient},
+		{PermissionError, CategoryClient},
+		{InternalError, CategoryServer},
+		{ExternalError, CategoryServer},
+		{ErrorType(999), CategoryUnknown},
+	}
+
+	for _, test := range tests {
+		t.Run(test.errorType.String(), func(t *testing.T) {
+			if got := test.errorType.Category(); got != test.expected {
+				t.Errorf("ErrorType.Category() = %v, want %v", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestCategory_String(t *testing.T) {
+	tests := []struct {
+		category Category
+		expected string
+	}{
+		{CategoryClient, "client"},
+		{CategoryServer, "server"},
+		{CategoryUnknown, "unknown"},
+	}
+
+	for _, test := range tests {
+		if got := test.category.String(); got != test.expected {
+			t.Errorf("Category.String() = %v, want %v", got, test.expected)
+		}
+	}
+}
+
+func TestIsClientError(t *testing.T) {
+	if !IsClientError(New(ValidationError, "test")) {
+		t.Error("IsClientError() should return true for validation errors")
+	}
+
+	if !IsClientError(fmt.Errorf("wrapped: %w", New(NotFoundError, "test"))) {
+		t.Error("IsClientError() should find wrapped client errors")
+	}
+
+	if IsClientError(New(InternalError, "test")) {
+		t.Error("IsClientError() should return false for internal errors")
+	}
+
+	if IsClientError(errors.New("plain")) {
+		t.Error("IsClientError() should return false for plain errors")
+	}
+}
+
+func TestIsServerError(t *testing.T) {
+	if !IsServerError(New(ExternalError, "test")) {
+		t.Error("IsServerError() should return true for external errors")
+	}
+
+	if IsServerError(New(PermissionError, "test")) {
+		t.Error("IsServerError() should return false for permission errors")
+	}
+
+	if IsServerError(errors.New("plain")) {
+		t.Error("IsServerError() should return false for plain errors")
+	}
+}
+
+func TestSetCategory(t *testing.T) {
+	const RateLimitError ErrorType = 100
+	defer func() {
+		categoriesMu.Lock()
+		delete(categories, RateLimitError)
+		categoriesMu.Unlock()
+	}()
+
+	if RateLimitError.Category() != CategoryUnknown {
+		t.Error("Custom error type should have no category by default")
+	}
+
+	SetCategory(RateLimitError, CategoryClient)
+
+	if !IsClientError(New(RateLimitError, "too many requests")) {
+		t.Error("IsClientError() should use the configured category")
+	}
+}
@@ -6,7 +6,10 @@
 package errmgt
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"sync/atomic"
 )
 
 // ErrorType represents the category of an error
@@ -51,16 +54,66 @@ type ManagedError struct {
 	Context map[string]interface{}
 }
 
-// Error implements the error interface
+var maxChainDisplay atomic.Int64
+
+// SetMaxChainDisplay limits how many causes Error renders after the
+// error's own message. When the chain is longer, the rest is summarized as
+// "... (N more)". A value of zero or less, the default, renders the whole
+// chain.
+func SetMaxChainDisplay(n int) {
+	maxChainDisplay.Store(int64(n))
+}
+
+// Error implements the error interface. A nil error renders as "<nil>".
 func (me *ManagedError) Error() string {
-	if me.Cause != nil {
-		return fmt.Sprintf("[%s] %s: %v", me.Type, me.Message, me.Cause)
+	if me == nil {
+		return "<nil>"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s] %s", me.Type, me.Message)
+
+	limit := int(maxChainDisplay.Load())
+	cause := me.Cause
+	for level := 0; cause != nil; level++ {
+		if limit > 0 && level >= limit {
+			fmt.Fprintf(&b, ": ... (%d more)", chainLength(cause))
+			break
+		}
+		next, ok := cause.(*ManagedError)
+		if !ok || next == nil {
+			fmt.Fprintf(&b, ": %v", cause)
+			break
+		}
+		fmt.Fprintf(&b, ": [%s] %s", next.Type, next.Message)
+		cause = next.Cause
 	}
-	return fmt.Sprintf("[%s] %s", me.Type, me.Message)
+	return b.String()
+}
+
+// chainLength counts the errors in err's unwrap chain, including err
+func chainLength(err error) int {
+	n := 0
+	for ; err != nil; err = errors.Unwrap(err) {
+		n++
+	}
+	return n
 }
 
 // Unwrap returns the underlying cause error
 func (me *ManagedError) Unwrap() error {
+	if me == nil {
+		return nil
+	}
+	return me.Cause
+}
+
+// GetCause returns the error's direct cause without unwrapping further. It
+// is the same as Unwrap, named for inspection code that reads more clearly
+// without the errors package. It returns nil for a nil error.
+func (me *ManagedError) GetCause() error {
+	if me == nil {
+		return nil
+	}
 	return me.Cause
 }
 
@@ -73,6 +126,16 @@ func New(errorType ErrorType, message string) *ManagedError {
 	}
 }
 
+// Newf creates a new ManagedError with a formatted message. When no
+// arguments are given the format is used as a literal message.
+func Newf(errorType ErrorType, format string, args ...interface{}) *ManagedError {
+	message := format
+	if len(args) > 0 {
+		message = fmt.Sprintf(format, args...)
+	}
+	return New(errorType, message)
+}
+
 // Wrap wraps an existing error with additional context and type
 func Wrap(err error, errorType ErrorType, message string) *ManagedError {
 	return &ManagedError{
@@ -83,19 +146,29 @@ func Wrap(err error, errorType ErrorType, message string) *ManagedError {
 	}
 }
 
-// WithContext adds context information to the error
+// WithContext adds context information to the error. Calling it on a nil
+// error is a no-op that returns nil.
 func (me *ManagedError) WithContext(key string, value interface{}) *ManagedError {
+	if me == nil {
+		return nil
+	}
+	if me.Context == nil {
+		me.Context = make(map[string]interface{})
+	}
 	me.Context[key] = value
 	return me
 }
 
 // GetContext retrieves context information from the error
 func (me *ManagedError) GetContext(key string) (interface{}, bool) {
+	if me == nil {
+		return nil, false
+	}
 	value, exists := me.Context[key]
 	return value, exists
 }
 
 // IsType checks if the error is of a specific type
 func (me *ManagedError) IsType(errorType ErrorType) bool {
-	return me.Type == errorType
+	return me != nil && me.Type == errorType
 }
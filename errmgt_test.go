@@ -2,6 +2,7 @@ package errmgt
 
 import (
 	"errors"
+	"strings"
 	"testing"
 )
 
@@ -47,6 +48,26 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNewf(t *testing.T) {
+	err := Newf(NotFoundError, "user %d not found", 42)
+
+	if err.Type != NotFoundError {
+		t.Errorf("Newf() Type = %v, want %v", err.Type, NotFoundError)
+	}
+
+	if err.Message != "user 42 not found" {
+		t.Errorf("Newf() Message = %v, want %v", err.Message, "user 42 not found")
+	}
+
+	// Without arguments the format is taken literally. Newf is called through
+	// a variable so vet does not flag the deliberately bare %.
+	newf := Newf
+	literal := newf(ValidationError, "discount must be below 100%")
+	if literal.Message != "discount must be below 100%" {
+		t.Errorf("Newf() Message = %v, want %v", literal.Message, "discount must be below 100%")
+	}
+}
+
 func TestWrap(t *testing.T) {
 	originalErr := errors.New("original error")
 	wrappedErr := Wrap(originalErr, InternalError, "wrapped error message")
@@ -106,6 +127,20 @@ func TestManagedError_Unwrap(t *testing.T) {
 	}
 }
 
+func TestManagedError_GetCause(t *testing.T) {
+	root := errors.New("root")
+	middle := Wrap(root, InternalError, "middle")
+	outer := Wrap(middle, InternalError, "outer")
+
+	if cause := outer.GetCause(); cause != middle {
+		t.Errorf("ManagedError.GetCause() = %v, want the direct cause %v", cause, middle)
+	}
+
+	if cause := New(ValidationError, "test").GetCause(); cause != nil {
+		t.Errorf("ManagedError.GetCause() = %v, want nil for error without cause", cause)
+	}
+}
+
 func TestManagedError_WithContext(t *testing.T) {
 	err := New(ValidationError, "test error")
 	err.WithContext("userId", 123)
@@ -145,3 +180,96 @@ func TestErrorsIs(t *testing.T) {
 		t.Error("errors.Is should work with wrapped ManagedError")
 	}
 }
+
+func TestManagedError_NilReceiver(t *testing.T) {
+	var me *ManagedError
+
+	if got := me.Error(); got != "<nil>" {
+		t.Errorf("Error() = %q, want %q", got, "<nil>")
+	}
+	if me.Unwrap() != nil {
+		t.Error("Unwrap() should return nil for a nil error")
+	}
+	if me.GetCause() != nil {
+		t.Error("GetCause() should return nil for a nil error")
+	}
+	if me.IsType(ValidationError) {
+		t.Error("IsType() should return false for a nil error")
+	}
+	if value, exists := me.GetContext("field"); value != nil || exists {
+		t.Errorf("GetContext() = %v, %v, want nil, false", value, exists)
+	}
+	if me.WithContext("field", "email") != nil {
+		t.Error("WithContext() should return nil for a nil error")
+	}
+
+	var err error = me
+	if ExitCode(err) != 1 {
+		t.Errorf("ExitCode(typed nil) = %d", ExitCode(err))
+	}
+	if IsClientError(err) || IsServerError(err) {
+		t.Error("typed nil should not have a category")
+	}
+}
+
+func TestSetMaxChainDisplay(t *testing.T) {
+	err := Wrap(Wrap(Wrap(errors.New("root"), ExternalError, "level 3"), InternalError, "level 2"), NotFoundError, "level 1")
+	top := Wrap(err, PermissionError, "top")
+
+	full := "[PermissionError] top: [NotFoundError] level 1: [InternalError] level 2: [ExternalError] level 3: root"
+	if got := top.Error(); got != full {
+		t.Errorf("Error() = %q, want %q", got, full)
+	}
+
+	SetMaxChainDisplay(2)
+	defer SetMaxChainDisplay(0)
+
+	expected := "[PermissionError] top: [NotFoundError] level 1: [InternalError] level 2: ... (2 more)"
+	if got := top.Error(); got != expected {
+		t.Errorf("Error() = %q, want %q", got, expected)
+	}
+
+	SetMaxChainDisplay(4)
+	if got := top.Error(); got != full {
+		t.Errorf("Error() = %q, want %q", got, full)
+	}
+}
+
+func TestManagedError_ErrorNesting(t *testing.T) {
+	root := errors.New("connection refused")
+
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name:     "two levels",
+			err:      Wrap(Wrap(root, ExternalError, "fetch failed"), InternalError, "sync failed"),
+			expected: "[InternalError] sync failed: [ExternalError] fetch failed: connection refused",
+		},
+		{
+			name: "three levels",
+			err: Wrap(Wrap(Wrap(root, ExternalError, "fetch failed"), InternalError, "sync failed"),
+				PermissionError, "import denied"),
+			expected: "[PermissionError] import denied: [InternalError] sync failed: [ExternalError] fetch failed: connection refused",
+		},
+		{
+			name:     "two levels without root cause",
+			err:      Wrap(New(NotFoundError, "user not found"), InternalError, "lookup failed"),
+			expected: "[InternalError] lookup failed: [NotFoundError] user not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.err.Error()
+			if got != tt.expected {
+				t.Errorf("Error() = %q, want %q", got, tt.expected)
+			}
+			if strings.Count(got, root.Error()) > 1 {
+				t.Errorf("Error() repeats the root cause: %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package errmgt
+
+import (
+	"errors"
+	"sync"
+)
+
+// defaultExitCodes maps error types to conventional process exit codes
+var defaultExitCodes = map[ErrorType]int{
+	InternalError:   1,
+	ValidationError: 2,
+	NotFoundError:   3,
+	PermissionError: 4,
+	ExternalError:   5,
+}
+
+var (
+	exitCodesMu sync.RWMutex
+	exitCodes   = copyExitCodes(defaultExitCodes)
+)
+
+// SetExitCodes overrides the exit codes returned by ExitCode for the given
+// error types. Types not present in codes keep their current exit code.
+func SetExitCodes(codes map[ErrorType]int) {
+	exitCodesMu.Lock()
+	defer exitCodesMu.Unlock()
+	for errorType, code := range codes {
+		exitCodes[errorType] = code
+	}
+}
+
+// ResetExitCodes restores the default exit code mapping
+func ResetExitCodes() {
+	exitCodesMu.Lock()
+	defer exitCodesMu.Unlock()
+	exitCodes = copyExitCodes(defaultExitCodes)
+}
+
+// ExitCode returns the process exit code for an error, suitable for
+// passing to os.Exit. It returns 0 for nil, the code mapped to the error's
+// type for a ManagedError, and 1 for any other error.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var managedErr *ManagedError
+	if errors.As(err, &managedErr) && managedErr != nil {
+		exitCodesMu.RLock()
+		defer exitCodesMu.RUnlock()
+		if code, exists := exitCodes[managedErr.Type]; exists {
+			return code
+		}
+	}
+	return 1
+}
+
+func copyExitCodes(codes map[ErrorType]int) map[ErrorType]int {
+	out := make(map[ErrorType]int, len(codes))
+	for errorType, code := range codes {
+		out[errorType] = code
+	}
+	return out
+}
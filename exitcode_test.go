@@ -0,0 +1,52 @@
+package errmgt
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"nil", nil, 0},
+		{"internal", New(InternalError, "test"), 1},
+		{"validation", New(ValidationError, "test"), 2},
+		{"not found", New(NotFoundError, "test"), 3},
+		{"permission", New(PermissionError, "test"), 4},
+		{"external", New(ExternalError, "test"), 5},
+		{"unknown type", New(ErrorType(999), "test"), 1},
+		{"plain error", errors.New("test"), 1},
+		{"wrapped", fmt.Errorf("context: %w", New(NotFoundError, "test")), 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := ExitCode(test.err); got != test.expected {
+				t.Errorf("ExitCode() = %v, want %v", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestSetExitCodes(t *testing.T) {
+	defer ResetExitCodes()
+
+	SetExitCodes(map[ErrorType]int{ValidationError: 64})
+
+	if got := ExitCode(New(ValidationError, "test")); got != 64 {
+		t.Errorf("ExitCode() = %v, want 64", got)
+	}
+
+	if got := ExitCode(New(NotFoundError, "test")); got != 3 {
+		t.Errorf("ExitCode() = %v, want 3 for a type that was not overridden", got)
+	}
+
+	ResetExitCodes()
+	if got := ExitCode(New(ValidationError, "test")); got != 2 {
+		t.Errorf("ExitCode() = %v, want 2 after reset", got)
+	}
+}
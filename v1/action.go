@@ -0,0 +1,38 @@
+package errmgt
+
+import "sync"
+
+var (
+	defaultActionsMu sync.RWMutex
+	defaultActions   = make(map[Code]string)
+)
+
+// SetDefaultAction associates a suggested action with code, so every error
+// created with that code carries it unless WithSuggestedAction overrides
+// it. An empty action removes the association.
+func SetDefaultAction(code Code, action string) {
+	defaultActionsMu.Lock()
+	defer defaultActionsMu.Unlock()
+	if action == "" {
+		delete(defaultActions, code)
+		return
+	}
+	defaultActions[code] = action
+}
+
+// defaultAction returns the suggested action associated with code
+func defaultAction(code Code) string {
+	defaultActionsMu.RLock()
+	defer defaultActionsMu.RUnlock()
+	return defaultActions[code]
+}
+
+// WithSuggestedAction sets a remediation hint describing what to do about
+// the error
+func (e *ManagedError) WithSuggestedAction(action string) *ManagedError {
+	if e == nil {
+		return nil
+	}
+	e.SuggestedAction = action
+	return e
+}
@@ -0,0 +1,51 @@
+package errmgt
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestManagedErrorWithSuggestedAction(t *testing.T) {
+	err := NewError(ExternalError, "api_timeout", "API timeout").
+		WithSuggestedAction("Check the upstream status page")
+
+	if got := fmt.Sprintf("%+v", err); !strings.Contains(got, "\n    action: Check the upstream status page") {
+		t.Errorf("Expected suggested action in verbose output, got\n%s", got)
+	}
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("Failed to marshal: %v", marshalErr)
+	}
+	if !strings.Contains(string(data), `"suggested_action":"Check the upstream status page"`) {
+		t.Errorf("Expected suggested_action in JSON, got %s", data)
+	}
+}
+
+func TestSetDefaultAction(t *testing.T) {
+	SetDefaultAction("disk_full", "Free space on the data volume")
+	SetDefaultAction("quota_exceeded", "Request a quota increase")
+	defer SetDefaultAction("disk_full", "")
+	defer SetDefaultAction("quota_exceeded", "")
+
+	err := NewError(SystemError, "disk_full", "Disk full")
+	if err.SuggestedAction != "Free space on the data volume" {
+		t.Errorf("Expected default action, got '%s'", err.SuggestedAction)
+	}
+
+	if err.WithCode("quota_exceeded").SuggestedAction != "Request a quota increase" {
+		t.Errorf("Expected action to follow the code, got '%s'", err.SuggestedAction)
+	}
+
+	overridden := NewError(SystemError, "disk_full", "Disk full").WithSuggestedAction("Page the on-call engineer")
+	if overridden.WithCode("quota_exceeded").SuggestedAction != "Page the on-call engineer" {
+		t.Errorf("Expected explicit action to be kept, got '%s'", overridden.SuggestedAction)
+	}
+
+	SetDefaultAction("disk_full", "")
+	if err := NewError(SystemError, "disk_full", "Disk full"); err.SuggestedAction != "" {
+		t.Errorf("Expected action to be removed, got '%s'", err.SuggestedAction)
+	}
+}
@@ -0,0 +1,56 @@
+package errmgt
+
+import (
+	"reflect"
+)
+
+// AsAll returns every ManagedError in err's tree in depth-first order. It
+// follows both Unwrap() error and Unwrap() []error, so errors aggregated in
+// a MultiError are all visited. Errors already visited are skipped, which
+// guards against cycles, and typed nil pointers are treated as absent.
+func AsAll(err error) []*ManagedError {
+	var found []*ManagedError
+	visited := make(map[error]bool)
+
+	var walk func(error)
+	walk = func(err error) {
+		if err == nil {
+			return
+		}
+		if reflect.TypeOf(err).Comparable() {
+			if visited[err] {
+				return
+			}
+			visited[err] = true
+		}
+
+		if managedErr, ok := err.(*ManagedError); ok && managedErr != nil {
+			found = append(found, managedErr)
+		}
+
+		switch x := err.(type) {
+		case interface{ Unwrap() error }:
+			walk(x.Unwrap())
+		case interface{ Unwrap() []error }:
+			for _, e := range x.Unwrap() {
+				walk(e)
+			}
+		}
+	}
+	walk(err)
+
+	return found
+}
+
+// PartitionByType groups the ManagedErrors returned by AsAll by their
+// type, preserving their depth-first order within each group. Like AsAll
+// it includes ManagedErrors wrapped as causes of other ManagedErrors.
+// Errors that are not ManagedErrors are left out; use AsAll or Flatten to
+// find them. It returns an empty map when err holds no ManagedErrors.
+func PartitionByType(err error) map[ErrorType][]*ManagedError {
+	partitions := make(map[ErrorType][]*ManagedError)
+	for _, managedErr := range AsAll(err) {
+		partitions[managedErr.Type] = append(partitions[managedErr.Type], managedErr)
+	}
+	return partitions
+}
@@ -0,0 +1,103 @@
+package errmgt
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestAsAll(t *testing.T) {
+	inner := NewError(ExternalError, "api_timeout", "API timeout")
+	outer := NewErrorWithCause(SystemError, "sync_failed", "Sync failed", inner)
+	validation := NewError(ValidationError, "invalid_email", "Invalid email")
+	business := NewError(BusinessError, "insufficient_funds", "Insufficient funds")
+
+	err := Append(
+		Wrap(outer, "syncing"),
+		errors.New("plain error"),
+		Append(validation, business),
+	)
+
+	found := AsAll(err)
+	expected := []*ManagedError{outer, inner, validation, business}
+	if len(found) != len(expected) {
+		t.Fatalf("Expected %d errors, got %d", len(expected), len(found))
+	}
+	for i := range expected {
+		if found[i] != expected[i] {
+			t.Errorf("Expected error %d to be %v, got %v", i, expected[i], found[i])
+		}
+	}
+}
+
+func TestAsAllNoManagedErrors(t *testing.T) {
+	if found := AsAll(nil); len(found) != 0 {
+		t.Errorf("Expected no errors for nil, got %v", found)
+	}
+
+	if found := AsAll(fmt.Errorf("wrapped: %w", errors.New("plain"))); len(found) != 0 {
+		t.Errorf("Expected no errors, got %v", found)
+	}
+}
+
+func TestAsAllCycle(t *testing.T) {
+	first := NewError(SystemError, "first", "First")
+	second := NewErrorWithCause(SystemError, "second", "Second", first)
+	first.Cause = second
+
+	found := AsAll(first)
+	if len(found) != 2 {
+		t.Errorf("Expected 2 errors, got %d", len(found))
+	}
+}
+
+func TestAsAllDuplicate(t *testing.T) {
+	shared := NewError(SystemError, "shared", "Shared")
+
+	found := AsAll(Append(shared, shared))
+	if len(found) != 1 {
+		t.Errorf("Expected shared error to be reported once, got %d", len(found))
+	}
+}
+
+func TestPartitionByType(t *testing.T) {
+	email := NewError(ValidationError, "invalid_email", "Invalid email")
+	name := NewError(ValidationError, "invalid_name", "Invalid name")
+	forbidden := NewError(BusinessError, "forbidden", "Forbidden")
+	db := NewError(SystemError, "db_error", "Database error")
+	audit := NewErrorWithCause(BusinessError, "audit_failed", "Audit failed", db)
+
+	err := Append(email, forbidden, errors.New("plain"), Wrap(name, "profile"), audit)
+	partitions := PartitionByType(err)
+
+	expected := map[ErrorType][]*ManagedError{
+		ValidationError: {email, name},
+		BusinessError:   {forbidden, audit},
+		SystemError:     {db},
+	}
+
+	if !reflect.DeepEqual(partitions, expected) {
+		t.Errorf("PartitionByType() = %v, want %v", partitions, expected)
+	}
+
+	if partitions := PartitionByType(errors.New("plain")); len(partitions) != 0 {
+		t.Errorf("Expected no partitions for a plain error, got %v", partitions)
+	}
+}
+
+func TestPartitionByTypeTypedNil(t *testing.T) {
+	var nilErr *ManagedError
+	email := NewError(ValidationError, "invalid_email", "Invalid email")
+
+	partitions := PartitionByType(&MultiError{Errors: []error{nilErr, email}})
+
+	expected := map[ErrorType][]*ManagedError{ValidationError: {email}}
+	if !reflect.DeepEqual(partitions, expected) {
+		t.Errorf("PartitionByType() = %v, want %v", partitions, expected)
+	}
+
+	if found := AsAll(nilErr); len(found) != 0 {
+		t.Errorf("Expected AsAll to skip a typed nil, got %v", found)
+	}
+}
@@ -0,0 +1,54 @@
+package errmgt
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// MaxAutoCodeLength is the maximum length of codes derived from messages
+// when SetAutoCodeFromMessage is enabled
+const MaxAutoCodeLength = 48
+
+var autoCodeFromMessage atomic.Bool
+
+// SetAutoCodeFromMessage controls whether errors without a code are
+// rendered with one derived from their message, so they can still be
+// grouped on dashboards. The derived code is the message in lowercase
+// snake_case, with runs of other characters replaced by an underscore and
+// truncated to MaxAutoCodeLength, so "Connection refused: 10.0.0.1"
+// becomes "connection_refused_10_0_0_1". It applies to Error(), JSON,
+// structured logging and everything else that renders EffectiveCode, such
+// as problem documents, CloudEvents and QualifiedCode; the Code field
+// itself stays empty. Normalize and
+// GetFingerprint derive the code from the normalized message instead, so
+// "User 123 not found" and "User 456 not found" group together as
+// "user_not_found". Disabled by default.
+func SetAutoCodeFromMessage(enabled bool) {
+	autoCodeFromMessage.Store(enabled)
+}
+
+// EffectiveCode returns the code the error is rendered with: its Code, or
+// with SetAutoCodeFromMessage and an empty Code, the code derived from its
+// message. Integrations that render the code should use it so they agree
+// with Error() and the JSON encoding. It returns an empty code for a nil
+// error.
+func (e *ManagedError) EffectiveCode() Code {
+	if e == nil {
+		return ""
+	}
+	if e.Code != "" || !autoCodeFromMessage.Load() {
+		return e.Code
+	}
+	return autoCode(e.GetMessage())
+}
+
+// autoCode derives a snake_case code from message
+func autoCode(message string) Code {
+	code := strings.Join(strings.FieldsFunc(strings.ToLower(message), func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	}), "_")
+	if len(code) > MaxAutoCodeLength {
+		code = strings.TrimRight(code[:MaxAutoCodeLength], "_")
+	}
+	return Code(code)
+}
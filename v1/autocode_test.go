@@ -0,0 +1,82 @@
+package errmgt
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSetAutoCodeFromMessage(t *testing.T) {
+	err := NewError(SystemError, "", "Connection refused: 10.0.0.1")
+
+	if got := err.Error(); got != "[system:] Connection refused: 10.0.0.1" {
+		t.Errorf("Expected no derived code by default, got %q", got)
+	}
+
+	SetAutoCodeFromMessage(true)
+	defer SetAutoCodeFromMessage(false)
+
+	if got := err.Error(); got != "[system:connection_refused_10_0_0_1] Connection refused: 10.0.0.1" {
+		t.Errorf("Error() = %q", got)
+	}
+
+	if err.Code != "" {
+		t.Errorf("Expected the Code field to stay empty, got %q", err.Code)
+	}
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("Failed to marshal error: %v", marshalErr)
+	}
+	if !strings.Contains(string(data), `"code":"connection_refused_10_0_0_1"`) {
+		t.Errorf("Expected the derived code in JSON, got %s", data)
+	}
+
+	if got := LogFields(err)["code"]; got != "connection_refused_10_0_0_1" {
+		t.Errorf("Expected the derived code in log fields, got %v", got)
+	}
+
+	if got := err.EffectiveCode(); got != "connection_refused_10_0_0_1" {
+		t.Errorf("EffectiveCode() = %q", got)
+	}
+
+	if got := QualifiedCode(err.WithScope("db")); got != "db.connection_refused_10_0_0_1" {
+		t.Errorf("Expected QualifiedCode to use the derived code, got %q", got)
+	}
+
+	other := NewError(SystemError, "", "Connection refused: 10.0.0.2")
+	if got, want := Normalize(err), Normalize(other); got != want || got != "system:connection_refused" {
+		t.Errorf("Expected errors differing only by IP to share a normalized code, got %q and %q", got, want)
+	}
+
+	first := GetFingerprint(NewError(BusinessError, "", "User 123 not found"))
+	second := GetFingerprint(NewError(BusinessError, "", "User 456 not found"))
+	if !reflect.DeepEqual(first, second) || !reflect.DeepEqual(first, []string{"business", "user_not_found"}) {
+		t.Errorf("Expected errors differing only by ID to share a fingerprint, got %q and %q", first, second)
+	}
+
+	coded := NewError(SystemError, "db_error", "Connection refused")
+	if got := coded.Error(); got != "[system:db_error] Connection refused" {
+		t.Errorf("Expected explicit codes to be kept, got %q", got)
+	}
+}
+
+func TestAutoCode(t *testing.T) {
+	tests := map[string]Code{
+		"Invalid email":                "invalid_email",
+		"  User   not found!  ":        "user_not_found",
+		"Ünïcode café":                 "n_code_caf",
+		"":                             "",
+		strings.Repeat("abcdefg ", 10): "abcdefg_abcdefg_abcdefg_abcdefg_abcdefg_abcdefg",
+	}
+
+	for message, want := range tests {
+		if got := autoCode(message); got != want {
+			t.Errorf("autoCode(%q) = %q, want %q", message, got, want)
+		}
+		if len(autoCode(message)) > MaxAutoCodeLength {
+			t.Errorf("autoCode(%q) is longer than %d", message, MaxAutoCodeLength)
+		}
+	}
+}
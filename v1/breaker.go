@@ -0,0 +1,172 @@
+package errmgt
+
+import (
+	"sync"
+	"time"
+)
+
+// DependencyKey is the context key GetDependency falls back to when an
+// error's Dependency field is empty
+const DependencyKey = "dependency"
+
+// BreakerObserver receives errors attributed to a dependency, for example
+// to track error rates and trip a circuit breaker
+type BreakerObserver interface {
+	Observe(err error)
+}
+
+// observerRegistration identifies one call to RegisterBreakerObserver, so
+// the same observer can be registered and unregistered more than once
+type observerRegistration struct {
+	observer BreakerObserver
+}
+
+var (
+	breakerObserversMu sync.RWMutex
+	breakerObservers   []*observerRegistration
+)
+
+// RegisterBreakerObserver registers o to receive every dependency error
+// reported through ObserveDependency. The returned function unregisters
+// it.
+func RegisterBreakerObserver(o BreakerObserver) (unregister func()) {
+	registered := &observerRegistration{observer: o}
+	breakerObserversMu.Lock()
+	breakerObservers = append(breakerObservers, registered)
+	breakerObserversMu.Unlock()
+
+	return func() {
+		breakerObserversMu.Lock()
+		defer breakerObserversMu.Unlock()
+		for i, registration := range breakerObservers {
+			if registration == registered {
+				breakerObservers = append(breakerObservers[:i:i], breakerObservers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// WithDependency attributes the error to the named dependency, such as
+// "payments-api". Tagging does not report the error, which is reported
+// once it is handled; see ObserveDependency.
+func (e *ManagedError) WithDependency(name string) *ManagedError {
+	if e == nil {
+		return nil
+	}
+	e.Dependency = name
+	return e
+}
+
+// GetDependency returns the dependency err is attributed to: the
+// Dependency field of the first ManagedError in its chain, or its
+// DependencyKey context entry when the field is empty
+func GetDependency(err error) string {
+	managedErr, ok := asManaged(err)
+	if !ok {
+		return ""
+	}
+	if managedErr.Dependency != "" {
+		return managedErr.Dependency
+	}
+	return managedErr.Context[DependencyKey]
+}
+
+// ObserveDependency reports err to the registered BreakerObservers if it
+// is attributed to a dependency, through either the Dependency field or
+// the DependencyKey context entry. Errors are reported automatically when
+// they are handled: the first Observe call on an error reports it, the
+// http package's WriteHTTP observes the errors it writes, and
+// RetryWithTimeout reports the failed attempts it retries. Call it
+// directly, once per failure, for errors handled some other way.
+func ObserveDependency(err error) {
+	if GetDependency(err) == "" {
+		return
+	}
+	breakerObserversMu.RLock()
+	registrations := append([]*observerRegistration(nil), breakerObservers...)
+	breakerObserversMu.RUnlock()
+
+	for _, registration := range registrations {
+		registration.observer.Observe(err)
+	}
+}
+
+// RateBreaker is a BreakerObserver that opens for a dependency when its
+// errors arrive faster than a threshold. It is safe for concurrent use.
+type RateBreaker struct {
+	threshold float64
+	window    time.Duration
+	now       func() time.Time
+
+	mu     sync.Mutex
+	events map[string][]time.Time
+}
+
+// NewRateBreaker creates a RateBreaker that opens for a dependency when
+// its observed errors within the trailing window reach threshold errors
+// per second
+func NewRateBreaker(threshold float64, window time.Duration) *RateBreaker {
+	return &RateBreaker{
+		threshold: threshold,
+		window:    window,
+		now:       currentTime,
+		events:    make(map[string][]time.Time),
+	}
+}
+
+// Observe implements BreakerObserver
+func (b *RateBreaker) Observe(err error) {
+	dependency := GetDependency(err)
+	if dependency == "" {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.now()
+	b.events[dependency] = append(b.prune(dependency, now), now)
+}
+
+// Open reports whether the breaker is open for any dependency
+func (b *RateBreaker) Open() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.now()
+	for dependency := range b.events {
+		if b.exceeded(dependency, now) {
+			return true
+		}
+	}
+	return false
+}
+
+// OpenFor reports whether the breaker is open for dependency
+func (b *RateBreaker) OpenFor(dependency string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.exceeded(dependency, b.now())
+}
+
+// exceeded reports whether dependency's error rate reached the threshold
+func (b *RateBreaker) exceeded(dependency string, now time.Time) bool {
+	events := b.prune(dependency, now)
+	return float64(len(events))/b.window.Seconds() >= b.threshold
+}
+
+// prune drops the events of dependency that fell out of the window and
+// returns the rest
+func (b *RateBreaker) prune(dependency string, now time.Time) []time.Time {
+	events := b.events[dependency]
+	i := 0
+	for i < len(events) && now.Sub(events[i]) >= b.window {
+		i++
+	}
+	events = events[i:]
+	if len(events) == 0 {
+		delete(b.events, dependency)
+		return nil
+	}
+	b.events[dependency] = events
+	return events
+}
@@ -0,0 +1,133 @@
+package errmgt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type recordingObserver struct {
+	errs []error
+}
+
+func (o *recordingObserver) Observe(err error) {
+	o.errs = append(o.errs, err)
+}
+
+func TestRegisterBreakerObserver(t *testing.T) {
+	observer := &recordingObserver{}
+	unregister := RegisterBreakerObserver(observer)
+
+	err := NewError(ExternalError, "api_timeout", "API timeout").WithDependency("payments-api")
+	if len(observer.errs) != 0 {
+		t.Fatalf("Expected WithDependency not to report the error, got %v", observer.errs)
+	}
+
+	ObserveDependency(err)
+	ObserveDependency(NewError(ExternalError, "api_timeout", "API timeout"))
+	ObserveDependency(NewError(ExternalError, "api_timeout", "API timeout").WithContext(DependencyKey, "ledger"))
+	ObserveDependency(errors.New("plain"))
+
+	if len(observer.errs) != 2 || observer.errs[0] != err {
+		t.Fatalf("Expected 2 dependency errors to be observed, got %v", observer.errs)
+	}
+
+	if GetDependency(observer.errs[1]) != "ledger" {
+		t.Errorf("Expected dependency from context, got '%s'", GetDependency(observer.errs[1]))
+	}
+
+	unregister()
+	ObserveDependency(err)
+	if len(observer.errs) != 2 {
+		t.Errorf("Expected no errors after unregistering, got %d", len(observer.errs))
+	}
+}
+
+func TestObserveReportsDependency(t *testing.T) {
+	observer := &recordingObserver{}
+	defer RegisterBreakerObserver(observer)()
+
+	err := NewError(ExternalError, "api_timeout", "API timeout").WithContext(DependencyKey, "ledger")
+	err.Observe().Observe()
+	NewError(ExternalError, "api_timeout", "API timeout").Observe()
+
+	if len(observer.errs) != 1 || observer.errs[0] != err {
+		t.Errorf("Expected the first Observe of a dependency error to report it once, got %v", observer.errs)
+	}
+}
+
+func TestRetryWithTimeoutReportsDependency(t *testing.T) {
+	observer := &recordingObserver{}
+	defer RegisterBreakerObserver(observer)()
+
+	err := RetryWithTimeout(context.Background(), RetryOptions{MaxAttempts: 3}, func(context.Context) error {
+		return NewError(ExternalError, "api_timeout", "API timeout").WithDependency("payments-api").WithRetryable(true)
+	})
+
+	if len(observer.errs) != 2 {
+		t.Fatalf("Expected the 2 retried attempts to be reported, got %v", observer.errs)
+	}
+
+	var managedErr *ManagedError
+	if errors.As(err, &managedErr) {
+		managedErr.Observe()
+	}
+	if len(observer.errs) != 3 {
+		t.Errorf("Expected the final error to be reported once handled, got %v", observer.errs)
+	}
+}
+
+func TestGetDependency(t *testing.T) {
+	err := NewError(ExternalError, "api_timeout", "API timeout").
+		WithContext(DependencyKey, "from-context")
+
+	if got := GetDependency(err); got != "from-context" {
+		t.Errorf("Expected dependency from context, got '%s'", got)
+	}
+
+	err.Dependency = "from-field"
+	if got := GetDependency(err); got != "from-field" {
+		t.Errorf("Expected the field to take precedence, got '%s'", got)
+	}
+
+	if got := GetDependency(errors.New("plain")); got != "" {
+		t.Errorf("Expected no dependency for a plain error, got '%s'", got)
+	}
+}
+
+func TestRateBreaker(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	breaker := NewRateBreaker(2, time.Second)
+	breaker.now = func() time.Time { return now }
+
+	unregister := RegisterBreakerObserver(breaker)
+	defer unregister()
+
+	ObserveDependency(NewError(ExternalError, "api_timeout", "API timeout").WithDependency("payments-api"))
+	if breaker.Open() {
+		t.Error("Expected the breaker to stay closed below the threshold")
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	ObserveDependency(NewError(ExternalError, "api_timeout", "API timeout").WithDependency("payments-api"))
+	ObserveDependency(NewError(ExternalError, "api_timeout", "API timeout").WithDependency("ledger"))
+
+	if !breaker.OpenFor("payments-api") || !breaker.Open() {
+		t.Error("Expected the breaker to open for payments-api at the threshold")
+	}
+
+	if breaker.OpenFor("ledger") {
+		t.Error("Expected the breaker to stay closed for ledger")
+	}
+
+	now = now.Add(600 * time.Millisecond)
+	if breaker.OpenFor("payments-api") {
+		t.Error("Expected the breaker to close once errors leave the window")
+	}
+
+	now = now.Add(time.Second)
+	if breaker.Open() || len(breaker.events) != 0 {
+		t.Errorf("Expected expired events to be pruned, got %v", breaker.events)
+	}
+}
@@ -0,0 +1,63 @@
+package errmgt
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+// OperationFailedCode is the error code used by WrapCaller
+const OperationFailedCode Code = "operation_failed"
+
+var captureCaller atomic.Bool
+
+// SetCaptureCaller enables or disables recording the file and line that
+// constructed each error in its Caller field, e.g. "service/user.go:42".
+// It costs a single runtime.Caller call per error, much less than a full
+// stack trace, and is disabled by default.
+func SetCaptureCaller(enabled bool) {
+	captureCaller.Store(enabled)
+}
+
+// caller returns the location skip frames above the caller of caller as
+// the file's parent directory, file name and line
+func caller(skip int) string {
+	_, file, line, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return ""
+	}
+	dir, name := filepath.Split(file)
+	return fmt.Sprintf("%s:%d", filepath.ToSlash(filepath.Join(filepath.Base(dir), name)), line)
+}
+
+// WrapCaller wraps err in a ManagedError whose message is the name of the
+// function calling WrapCaller, e.g. "service.(*UserService).Create". The
+// fully qualified name is also stored in the "operation" context key, and
+// the code is OperationFailedCode. A nil err returns nil.
+//
+// The name is looked up with runtime.Caller on every call, which is cheap
+// but not free; prefer NewErrorWithCause in hot paths.
+func WrapCaller(err error, errType ErrorType) *ManagedError {
+	if err == nil {
+		return nil
+	}
+	operation := "unknown"
+	if pc, _, _, ok := runtime.Caller(1); ok {
+		if fn := runtime.FuncForPC(pc); fn != nil {
+			operation = fn.Name()
+		}
+	}
+	return newError(1, errType, OperationFailedCode, shortFunctionName(operation), err).
+		WithContext("operation", operation)
+}
+
+// shortFunctionName strips the package path from a fully qualified
+// function name
+func shortFunctionName(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
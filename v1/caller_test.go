@@ -0,0 +1,75 @@
+package errmgt
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+type userService struct{}
+
+func (s *userService) create(cause error) *ManagedError {
+	return WrapCaller(cause, SystemError)
+}
+
+func TestWrapCaller(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := (&userService{}).create(cause)
+
+	if err.Message != "go-errmgt.(*userService).create" {
+		t.Errorf("Expected message to be the caller name, got '%s'", err.Message)
+	}
+
+	if err.Context["operation"] != "github.com/kerzzt/go-errmgt.(*userService).create" {
+		t.Errorf("Expected operation to be the qualified caller name, got '%s'", err.Context["operation"])
+	}
+
+	if err.Type != SystemError || err.Code != OperationFailedCode {
+		t.Errorf("Expected a %s error with code %s, got %v", SystemError, OperationFailedCode, err)
+	}
+
+	if !errors.Is(err, cause) {
+		t.Error("Expected error to wrap the cause")
+	}
+
+	if WrapCaller(nil, SystemError) != nil {
+		t.Error("Expected nil for a nil error")
+	}
+}
+
+func TestShortFunctionName(t *testing.T) {
+	tests := map[string]string{
+		"main.main": "main.main",
+		"github.com/acme/service.(*UserService).Create": "service.(*UserService).Create",
+		"github.com/acme/service.handler.func1":         "service.handler.func1",
+	}
+
+	for name, expected := range tests {
+		if got := shortFunctionName(name); got != expected {
+			t.Errorf("shortFunctionName(%q) = %q, want %q", name, got, expected)
+		}
+	}
+}
+
+func TestSetCaptureCaller(t *testing.T) {
+	if err := NewError(SystemError, "db_error", "Database error"); err.Caller != "" {
+		t.Errorf("Expected no caller by default, got '%s'", err.Caller)
+	}
+
+	SetCaptureCaller(true)
+	defer SetCaptureCaller(false)
+
+	_, _, line, _ := runtime.Caller(0)
+	err := NewError(SystemError, "db_error", "Database error")
+
+	expected := fmt.Sprintf("v1/caller_test.go:%d", line+1)
+	if err.Caller != expected {
+		t.Errorf("Expected caller '%s', got '%s'", expected, err.Caller)
+	}
+
+	if wrapped := WrapCaller(errors.New("cause"), SystemError); !strings.HasPrefix(wrapped.Caller, "v1/caller_test.go:") {
+		t.Errorf("Expected WrapCaller to record its caller, got '%s'", wrapped.Caller)
+	}
+}
@@ -0,0 +1,86 @@
+package errmgt
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Translation is the localized text of an error code
+type Translation struct {
+	Message string
+	Details string
+}
+
+var (
+	catalogMu sync.RWMutex
+	catalog   = make(map[string]map[Code]Translation)
+)
+
+// RegisterTranslations adds translations for lang, a language tag such as
+// "de" or "pt-BR", to the message catalog. Entries for codes already
+// registered for lang are replaced.
+func RegisterTranslations(lang string, translations map[Code]Translation) {
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+	entries, exists := catalog[lang]
+	if !exists {
+		entries = make(map[Code]Translation, len(translations))
+		catalog[lang] = entries
+	}
+	for code, translation := range translations {
+		entries[code] = translation
+	}
+}
+
+// translation looks up code for lang, falling back from a regional tag
+// such as "pt-BR" to its base language "pt"
+func translation(lang string, code Code) (Translation, bool) {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+	if t, exists := catalog[lang][code]; exists {
+		return t, true
+	}
+	if base, _, regional := strings.Cut(lang, "-"); regional {
+		t, exists := catalog[base][code]
+		return t, exists
+	}
+	return Translation{}, false
+}
+
+// LocalizedMessage returns the catalog message for the error's code in
+// lang, falling back to UserMessage and then Message
+func (e *ManagedError) LocalizedMessage(lang string) string {
+	if e == nil {
+		return ""
+	}
+	if t, ok := translation(lang, e.Code); ok && t.Message != "" {
+		return t.Message
+	}
+	if e.UserMessage != "" {
+		return e.UserMessage
+	}
+	return e.GetMessage()
+}
+
+// LocalizedDetails returns the catalog details for the error's code in
+// lang, falling back to Details
+func (e *ManagedError) LocalizedDetails(lang string) string {
+	if e == nil {
+		return ""
+	}
+	if t, ok := translation(lang, e.Code); ok && t.Details != "" {
+		return t.Details
+	}
+	return e.Details
+}
+
+// LocalizedUserError is the localized form of UserError, built from
+// LocalizedMessage and LocalizedDetails
+func (e *ManagedError) LocalizedUserError(lang string) string {
+	message := e.LocalizedMessage(lang)
+	if details := e.LocalizedDetails(lang); details != "" {
+		return fmt.Sprintf("%s: %s", message, details)
+	}
+	return message
+}
@@ -0,0 +1,65 @@
+package errmgt
+
+import "testing"
+
+func TestLocalizedUserError(t *testing.T) {
+	RegisterTranslations("de", map[Code]Translation{
+		"quota_exceeded": {
+			Message: "Kontingent überschritten",
+			Details: "Sie haben das monatliche Limit von Anfragen erreicht",
+		},
+	})
+	RegisterTranslations("fr", map[Code]Translation{
+		"quota_exceeded": {
+			Message: "Quota dépassé",
+			Details: "Vous avez atteint la limite mensuelle de requêtes",
+		},
+	})
+	defer func() {
+		catalogMu.Lock()
+		delete(catalog, "de")
+		delete(catalog, "fr")
+		catalogMu.Unlock()
+	}()
+
+	err := NewError(BusinessError, "quota_exceeded", "Quota exceeded").
+		WithDetails("You have reached the monthly request limit")
+
+	tests := map[string]string{
+		"de":    "Kontingent überschritten: Sie haben das monatliche Limit von Anfragen erreicht",
+		"fr":    "Quota dépassé: Vous avez atteint la limite mensuelle de requêtes",
+		"de-AT": "Kontingent überschritten: Sie haben das monatliche Limit von Anfragen erreicht",
+		"es":    "Quota exceeded: You have reached the monthly request limit",
+	}
+
+	for lang, expected := range tests {
+		if got := err.LocalizedUserError(lang); got != expected {
+			t.Errorf("LocalizedUserError(%q) = %q, want %q", lang, got, expected)
+		}
+	}
+}
+
+func TestLocalizedFallbacks(t *testing.T) {
+	RegisterTranslations("nl", map[Code]Translation{"invalid_email": {Message: "Ongeldig e-mailadres"}})
+	defer func() {
+		catalogMu.Lock()
+		delete(catalog, "nl")
+		catalogMu.Unlock()
+	}()
+
+	err := NewError(ValidationError, "invalid_email", "Invalid email").
+		WithUserMessage("Please check your email address").
+		WithDetails("missing @")
+
+	if got := err.LocalizedMessage("nl"); got != "Ongeldig e-mailadres" {
+		t.Errorf("Expected translated message, got '%s'", got)
+	}
+
+	if got := err.LocalizedDetails("nl"); got != "missing @" {
+		t.Errorf("Expected details to fall back to the stored details, got '%s'", got)
+	}
+
+	if got := err.LocalizedMessage("it"); got != "Please check your email address" {
+		t.Errorf("Expected message to fall back to the user message, got '%s'", got)
+	}
+}
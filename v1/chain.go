@@ -0,0 +1,69 @@
+package errmgt
+
+import (
+	"errors"
+	"reflect"
+)
+
+// Depth returns how many times err can be unwrapped with errors.Unwrap
+// before reaching the root cause. An error without a cause has depth 0, as
+// does nil. Chains that loop back on themselves stop at the first repeat.
+func Depth(err error) int {
+	depth := 0
+	walkChain(err, func(error) { depth++ })
+	if depth == 0 {
+		return 0
+	}
+	return depth - 1
+}
+
+// RootCause returns the deepest error in err's chain, following
+// errors.Unwrap. It returns err itself when it has no cause and nil for
+// nil.
+func RootCause(err error) error {
+	root := err
+	walkChain(err, func(e error) { root = e })
+	return root
+}
+
+// InnerType returns the type of the first ManagedError in the error's
+// cause chain, so the original classification stays visible after an
+// error is rewrapped at a domain boundary. It returns an empty ErrorType
+// when no cause is a ManagedError.
+func (e *ManagedError) InnerType() ErrorType {
+	if e == nil {
+		return ""
+	}
+	if inner, ok := asManaged(e.Cause); ok {
+		return inner.Type
+	}
+	return ""
+}
+
+// RootType returns the type of the deepest ManagedError in err's chain,
+// or an empty ErrorType when the chain has none
+func RootType(err error) ErrorType {
+	var root ErrorType
+	walkChain(err, func(e error) {
+		if managedErr, ok := e.(*ManagedError); ok && managedErr != nil {
+			root = managedErr.Type
+		}
+	})
+	return root
+}
+
+// walkChain calls fn for each error in err's single-unwrap chain, stopping
+// if an error repeats
+func walkChain(err error, fn func(error)) {
+	visited := make(map[error]bool)
+	for err != nil {
+		if reflect.TypeOf(err).Comparable() {
+			if visited[err] {
+				return
+			}
+			visited[err] = true
+		}
+		fn(err)
+		err = errors.Unwrap(err)
+	}
+}
@@ -0,0 +1,89 @@
+package errmgt
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestDepthAndRootCause(t *testing.T) {
+	root := errors.New("connection refused")
+	level1 := fmt.Errorf("dialing: %w", root)
+	level2 := NewErrorWithCause(ExternalError, "api_unavailable", "API unavailable", level1)
+	level3 := Wrap(level2, "syncing users")
+
+	tests := []struct {
+		name  string
+		err   error
+		depth int
+		root  error
+	}{
+		{"nil", nil, 0, nil},
+		{"root", root, 0, root},
+		{"one level", level1, 1, root},
+		{"two levels", level2, 2, root},
+		{"three levels", level3, 3, root},
+		{"managed without cause", NewError(SystemError, "db_error", "Database error"), 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Depth(tt.err); got != tt.depth {
+				t.Errorf("Depth() = %d, want %d", got, tt.depth)
+			}
+
+			want := tt.root
+			if want == nil {
+				want = tt.err
+			}
+			if got := RootCause(tt.err); got != want {
+				t.Errorf("RootCause() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestDepthCycle(t *testing.T) {
+	first := NewError(SystemError, "first", "First")
+	second := NewErrorWithCause(SystemError, "second", "Second", first)
+	first.Cause = second
+
+	if got := Depth(first); got != 1 {
+		t.Errorf("Depth() = %d, want 1", got)
+	}
+
+	if got := RootCause(first); got != second {
+		t.Errorf("RootCause() = %v, want %v", got, second)
+	}
+}
+
+func TestInnerTypeAndRootType(t *testing.T) {
+	root := NewErrorWithCause(ExternalError, "api_unavailable", "API unavailable", errors.New("connection refused"))
+	middle := NewErrorWithCause(SystemError, "sync_failed", "Sync failed", fmt.Errorf("syncing: %w", root))
+	outer := NewErrorWithCause(BusinessError, "order_failed", "Order failed", middle)
+
+	if got := outer.InnerType(); got != SystemError {
+		t.Errorf("InnerType() = %q, want %q", got, SystemError)
+	}
+
+	if got := middle.InnerType(); got != ExternalError {
+		t.Errorf("InnerType() through a plain wrapper = %q, want %q", got, ExternalError)
+	}
+
+	if got := root.InnerType(); got != "" {
+		t.Errorf("InnerType() without a managed cause = %q, want empty", got)
+	}
+
+	if got := RootType(Wrap(outer, "checkout")); got != ExternalError {
+		t.Errorf("RootType() = %q, want %q", got, ExternalError)
+	}
+
+	if got := RootType(errors.New("plain")); got != "" {
+		t.Errorf("RootType() of a plain error = %q, want empty", got)
+	}
+
+	var nilErr *ManagedError
+	if nilErr.InnerType() != "" || RootType(nil) != "" {
+		t.Error("Expected empty types for nil errors")
+	}
+}
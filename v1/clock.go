@@ -0,0 +1,41 @@
+package errmgt
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Clock supplies the current time to the package: error timestamps,
+// expiry and observation times, and the windows of Deduplicator, Sampler
+// and RateBreaker. Tests can install a fixed or fake clock with SetClock.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the default Clock, backed by time.Now
+type systemClock struct{}
+
+// Now implements Clock
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+var clock atomic.Pointer[Clock]
+
+// SetClock replaces the clock the package reads the time from. Passing
+// nil restores the system clock, which is the default.
+func SetClock(c Clock) {
+	if c == nil {
+		clock.Store(nil)
+		return
+	}
+	clock.Store(&c)
+}
+
+// currentTime returns the time according to the configured Clock
+func currentTime() time.Time {
+	if c := clock.Load(); c != nil {
+		return (*c).Now()
+	}
+	return systemClock{}.Now()
+}
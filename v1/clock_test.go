@@ -0,0 +1,91 @@
+package errmgt
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock whose time only moves when advanced
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func TestSetClock(t *testing.T) {
+	c := &fakeClock{now: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)}
+	SetClock(c)
+	defer SetClock(nil)
+
+	err := NewError(ExternalError, "service_unavailable", "Service unavailable").WithExpiry(time.Minute)
+
+	if !err.Timestamp.Equal(c.now) {
+		t.Errorf("Expected the timestamp from the clock, got %v", err.Timestamp)
+	}
+
+	if want := c.now.Add(time.Minute); !err.ExpiresAt.Equal(want) {
+		t.Errorf("Expected expiry %v, got %v", want, err.ExpiresAt)
+	}
+
+	c.now = c.now.Add(59 * time.Second)
+	if IsExpired(err) {
+		t.Error("Expected the error not to be expired before its expiry")
+	}
+
+	c.now = c.now.Add(time.Second)
+	if !IsExpired(err) {
+		t.Error("Expected the error to be expired at its expiry")
+	}
+
+	if d := err.Observe().PropagationTime(); d != time.Minute {
+		t.Errorf("Expected a propagation time of a minute, got %v", d)
+	}
+}
+
+func TestSetClockWindows(t *testing.T) {
+	c := &fakeClock{now: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)}
+	SetClock(c)
+	defer SetClock(nil)
+
+	d := NewDeduplicator(time.Minute)
+	s := NewSampler(1, time.Minute)
+	err := NewError(SystemError, "db_error", "Database error")
+
+	if d.Seen(err) || !s.Allow(err) {
+		t.Fatal("Expected the first error to be let through")
+	}
+
+	if !d.Seen(err) || s.Allow(err) {
+		t.Error("Expected a repeat within the window to be suppressed")
+	}
+
+	c.now = c.now.Add(time.Minute)
+	if d.Seen(err) || !s.Allow(err) {
+		t.Error("Expected the windows to follow the clock")
+	}
+}
+
+func TestSystemClockByDefault(t *testing.T) {
+	before := time.Now()
+	if now := currentTime(); now.Before(before) || now.After(time.Now()) {
+		t.Errorf("Expected the system time, got %v", now)
+	}
+}
+
+func TestSetClockDeadline(t *testing.T) {
+	c := &fakeClock{now: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)}
+	SetClock(c)
+	defer SetClock(nil)
+
+	ctx, cancel := context.WithDeadline(context.Background(), c.now.Add(-2*time.Second))
+	defer cancel()
+
+	err := NewFromDeadline(ctx, "lookup_timeout", "Lookup timed out")
+
+	if overrun, ok := GetContextDuration(err, "overrun"); !ok || overrun != 2*time.Second {
+		t.Errorf("Expected an overrun of 2s from the clock, got %v, %v", overrun, ok)
+	}
+}
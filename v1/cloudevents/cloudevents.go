@@ -0,0 +1,96 @@
+// Package cloudevents converts errmgt errors into CloudEvents for
+// publishing to event-driven pipelines.
+package cloudevents
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync/atomic"
+	"time"
+
+	"github.com/kerzzt/go-errmgt"
+)
+
+const (
+	// SpecVersion is the CloudEvents specification version events follow
+	SpecVersion = "1.0"
+	// DefaultTypePrefix is prepended to the error code to form the event
+	// type unless changed with SetTypePrefix
+	DefaultTypePrefix = "com.github.kerzzt.errmgt.error."
+	// DefaultSource is the event source of errors without a component
+	DefaultSource = "errmgt"
+	// DataContentType is the media type of event data
+	DataContentType = "application/json"
+)
+
+// Event is a CloudEvents 1.0 event in the structured JSON format
+type Event struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Time            time.Time       `json:"time,omitzero"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	Data            json.RawMessage `json:"data,omitempty"`
+}
+
+var typePrefix atomic.Value
+
+// SetTypePrefix sets the prefix event types are built from, such as
+// "com.example.error.", so an error with code "invalid_email" gets the
+// type "com.example.error.invalid_email". An empty prefix restores
+// DefaultTypePrefix.
+func SetTypePrefix(prefix string) {
+	typePrefix.Store(prefix)
+}
+
+// eventType returns the event type for code
+func eventType(code errmgt.Code) string {
+	prefix, _ := typePrefix.Load().(string)
+	if prefix == "" {
+		prefix = DefaultTypePrefix
+	}
+	return prefix + string(code)
+}
+
+// ToEvent builds the CloudEvent for err, converted with errmgt.Ensure. The
+// event's type is the type prefix followed by the error code, its source
+// the error's Component or DefaultSource, its time the error's Timestamp
+// and its data the error's JSON encoding. The error ID is the event ID;
+// errors without one get an ID derived from the data, so the same error
+// always maps to the same event. A nil error produces the zero Event.
+func ToEvent(err error) Event {
+	managedErr := errmgt.Ensure(err)
+	if managedErr == nil {
+		return Event{}
+	}
+
+	data, marshalErr := json.Marshal(managedErr)
+	if marshalErr != nil {
+		// Metadata that cannot be encoded; fall back to the essentials
+		data, _ = json.Marshal(map[string]string{
+			"type":    string(managedErr.Type),
+			"code":    string(managedErr.EffectiveCode()),
+			"message": managedErr.GetMessage(),
+		})
+	}
+
+	event := Event{
+		SpecVersion:     SpecVersion,
+		ID:              managedErr.GetID(),
+		Source:          managedErr.Component,
+		Type:            eventType(managedErr.EffectiveCode()),
+		Time:            managedErr.Timestamp,
+		DataContentType: DataContentType,
+		Data:            data,
+	}
+	if event.ID == "" {
+		sum := sha256.Sum256(data)
+		event.ID = hex.EncodeToString(sum[:16])
+	}
+	if event.Source == "" {
+		event.Source = DefaultSource
+	}
+	return event
+}
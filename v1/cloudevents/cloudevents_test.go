@@ -0,0 +1,117 @@
+package cloudevents
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kerzzt/go-errmgt"
+)
+
+func TestToEvent(t *testing.T) {
+	managedErr := errmgt.NewError(errmgt.ValidationError, "invalid_email", "Invalid email").
+		WithComponent("accounts").
+		WithContext("field", "email")
+
+	event := ToEvent(errmgt.Wrap(managedErr, "signup"))
+
+	if event.SpecVersion != SpecVersion {
+		t.Errorf("Expected spec version %s, got %s", SpecVersion, event.SpecVersion)
+	}
+
+	if event.ID != managedErr.GetID() {
+		t.Errorf("Expected the error ID, got %s", event.ID)
+	}
+
+	if event.Source != "accounts" {
+		t.Errorf("Expected the component as source, got %s", event.Source)
+	}
+
+	if event.Type != DefaultTypePrefix+"invalid_email" {
+		t.Errorf("Unexpected event type %s", event.Type)
+	}
+
+	if !event.Time.Equal(managedErr.Timestamp) {
+		t.Errorf("Expected the error timestamp, got %v", event.Time)
+	}
+
+	if event.DataContentType != DataContentType {
+		t.Errorf("Unexpected data content type %s", event.DataContentType)
+	}
+
+	var decoded errmgt.ManagedError
+	if err := json.Unmarshal(event.Data, &decoded); err != nil {
+		t.Fatalf("Failed to decode event data: %v", err)
+	}
+
+	if decoded.Code != "invalid_email" || decoded.Context["field"] != "email" {
+		t.Errorf("Expected the error as data, got %+v", decoded)
+	}
+}
+
+func TestToEventDefaults(t *testing.T) {
+	errmgt.SetIDGenerator(nil)
+	defer errmgt.ResetIDGenerator()
+
+	managedErr := errmgt.NewError(errmgt.SystemError, "db_error", "Database error")
+	managedErr.Timestamp = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	event := ToEvent(managedErr)
+
+	if event.Source != DefaultSource {
+		t.Errorf("Expected the default source, got %s", event.Source)
+	}
+
+	if event.ID == "" || event.ID != ToEvent(managedErr).ID {
+		t.Errorf("Expected a stable ID derived from the data, got %q", event.ID)
+	}
+
+	if ToEvent(errors.New("plain")).Type != DefaultTypePrefix+string(errmgt.InternalErrorCode) {
+		t.Error("Expected plain errors to be converted with Ensure")
+	}
+
+	if event := ToEvent(nil); event.SpecVersion != "" {
+		t.Errorf("Expected the zero event for nil, got %+v", event)
+	}
+}
+
+func TestSetTypePrefix(t *testing.T) {
+	SetTypePrefix("com.example.error.")
+	defer SetTypePrefix("")
+
+	event := ToEvent(errmgt.NewError(errmgt.ValidationError, "invalid_email", "Invalid email"))
+	if event.Type != "com.example.error.invalid_email" {
+		t.Errorf("Unexpected event type %s", event.Type)
+	}
+}
+
+func TestToEventAutoCode(t *testing.T) {
+	errmgt.SetAutoCodeFromMessage(true)
+	defer errmgt.SetAutoCodeFromMessage(false)
+
+	event := ToEvent(errmgt.NewError(errmgt.SystemError, "", "Database error"))
+	if event.Type != DefaultTypePrefix+"database_error" {
+		t.Errorf("Expected the derived code in the event type, got %s", event.Type)
+	}
+}
+
+func TestEventJSON(t *testing.T) {
+	event := ToEvent(errmgt.NewError(errmgt.ValidationError, "invalid_email", "Invalid email"))
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("Failed to marshal event: %v", err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Failed to unmarshal event: %v", err)
+	}
+
+	for _, attribute := range []string{"specversion", "id", "source", "type", "time", "datacontenttype", "data"} {
+		if _, exists := fields[attribute]; !exists {
+			t.Errorf("Expected attribute %s in %s", attribute, data)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package errmgt
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// CodeErrorKey is the context key under which a code validation failure is
+// reported
+const CodeErrorKey = "code_error"
+
+var codeValidator atomic.Pointer[func(string) error]
+
+var caseInsensitiveCodes atomic.Bool
+
+var snakeCasePattern = regexp.MustCompile(`^[a-z0-9]+(_[a-z0-9]+)*$`)
+
+// SetCodeValidator installs a function that checks every code passed to
+// NewError, its variants and WithCode. Codes are never rejected outright;
+// when validation fails the error is still created and the failure is
+// reported in its context under CodeErrorKey, so malformed codes show up in
+// logs and tests. Passing nil removes the validator, which is the default.
+func SetCodeValidator(validate func(code string) error) {
+	if validate == nil {
+		codeValidator.Store(nil)
+		return
+	}
+	codeValidator.Store(&validate)
+}
+
+// SnakeCaseCode is a code validator that accepts lowercase snake_case codes
+// such as "invalid_email"
+func SnakeCaseCode(code string) error {
+	if !snakeCasePattern.MatchString(code) {
+		return fmt.Errorf("errmgt: code %q is not lowercase snake_case", code)
+	}
+	return nil
+}
+
+// SetCaseInsensitiveCodes controls whether codes that differ only in case,
+// such as "INVALID_EMAIL" and "invalid_email", are considered equal when
+// errors are compared with errors.Is. Comparison is case-sensitive by
+// default.
+func SetCaseInsensitiveCodes(enabled bool) {
+	caseInsensitiveCodes.Store(enabled)
+}
+
+// codesEqual compares two codes, honoring SetCaseInsensitiveCodes
+func codesEqual(a, b Code) bool {
+	if caseInsensitiveCodes.Load() {
+		return strings.EqualFold(string(a), string(b))
+	}
+	return a == b
+}
+
+// MatchByCodeOnly marks the error as a sentinel that errors.Is matches by
+// code alone, for registries where codes are unique across types:
+//
+//	var ErrQuotaExceeded = errmgt.NewError(errmgt.BusinessError, "quota_exceeded", "").MatchByCodeOnly()
+//
+// errors.Is(err, ErrQuotaExceeded) then reports any ManagedError with code
+// "quota_exceeded" in err's chain, whatever its type. Only the target of
+// the comparison is consulted: marking an error that is being inspected
+// has no effect, and sentinels that are not marked keep matching on both
+// type and code. SetCaseInsensitiveCodes still applies.
+func (e *ManagedError) MatchByCodeOnly() *ManagedError {
+	if e == nil {
+		return nil
+	}
+	e.matchCodeOnly = true
+	return e
+}
+
+// WithCode sets the code of the error. A help URL or suggested action
+// derived from the previous code is replaced by the one for the new code.
+func (e *ManagedError) WithCode(code Code) *ManagedError {
+	if e == nil {
+		return nil
+	}
+	if e.HelpURL == helpURL(e.Code) {
+		e.HelpURL = helpURL(code)
+	}
+	if e.SuggestedAction == defaultAction(e.Code) {
+		e.SuggestedAction = defaultAction(code)
+	}
+	e.Code = code
+	e.validateCode()
+	return e
+}
+
+// validateCode reports a code rejected by the configured validator
+func (e *ManagedError) validateCode() {
+	validate := codeValidator.Load()
+	if validate == nil {
+		return
+	}
+	if err := (*validate)(string(e.Code)); err != nil {
+		e.WithContext(CodeErrorKey, err.Error())
+	}
+}
@@ -0,0 +1,105 @@
+package errmgt
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSnakeCaseCode(t *testing.T) {
+	valid := []string{"invalid_email", "timeout", "http_404", "a_b_c"}
+	for _, code := range valid {
+		if err := SnakeCaseCode(code); err != nil {
+			t.Errorf("Expected %q to be valid, got %v", code, err)
+		}
+	}
+
+	invalid := []string{"InvalidEmail", "invalid email", "invalid-email", "_leading", "trailing_", "double__underscore", ""}
+	for _, code := range invalid {
+		if err := SnakeCaseCode(code); err == nil {
+			t.Errorf("Expected %q to be invalid", code)
+		}
+	}
+}
+
+func TestSetCodeValidator(t *testing.T) {
+	SetCodeValidator(SnakeCaseCode)
+	defer SetCodeValidator(nil)
+
+	valid := NewError(ValidationError, "invalid_email", "Invalid email")
+	if _, exists := valid.Context[CodeErrorKey]; exists {
+		t.Error("Expected no code error for a valid code")
+	}
+
+	invalid := NewError(ValidationError, "InvalidEmail", "Invalid email")
+	if invalid.Code != "InvalidEmail" {
+		t.Error("Expected the code to be kept")
+	}
+	if _, exists := invalid.Context[CodeErrorKey]; !exists {
+		t.Error("Expected a code error for an invalid code")
+	}
+
+	withCode := NewError(ValidationError, "invalid_email", "Invalid email").WithCode("invalid email")
+	if withCode.Code != "invalid email" {
+		t.Errorf("Expected WithCode to set the code, got '%s'", withCode.Code)
+	}
+	if _, exists := withCode.Context[CodeErrorKey]; !exists {
+		t.Error("Expected a code error for an invalid code set with WithCode")
+	}
+}
+
+func TestCodeValidatorPermissiveByDefault(t *testing.T) {
+	err := NewError(ValidationError, "Invalid Email", "Invalid email")
+
+	if _, exists := err.Context[CodeErrorKey]; exists {
+		t.Error("Expected no code validation by default")
+	}
+}
+
+func TestSetCaseInsensitiveCodes(t *testing.T) {
+	upper := NewError(ValidationError, "INVALID_EMAIL", "Invalid email")
+	lower := NewError(ValidationError, "invalid_email", "Invalid email")
+
+	if errors.Is(upper, lower) {
+		t.Error("Expected codes to be compared case-sensitively by default")
+	}
+
+	SetCaseInsensitiveCodes(true)
+	defer SetCaseInsensitiveCodes(false)
+
+	if !errors.Is(upper, lower) {
+		t.Error("Expected codes differing only in case to match")
+	}
+
+	if errors.Is(upper, NewError(BusinessError, "invalid_email", "Invalid email")) {
+		t.Error("Expected the type to still be compared")
+	}
+}
+
+func TestMatchByCodeOnly(t *testing.T) {
+	sentinel := NewError(BusinessError, "quota_exceeded", "").MatchByCodeOnly()
+	err := Wrap(NewError(ExternalError, "quota_exceeded", "Quota exceeded"), "upload")
+
+	if !errors.Is(err, sentinel) {
+		t.Error("Expected a code-only sentinel to match regardless of type")
+	}
+
+	if errors.Is(err, NewError(BusinessError, "quota_exceeded", "")) {
+		t.Error("Expected unmarked sentinels to still compare the type")
+	}
+
+	if errors.Is(NewError(ExternalError, "rate_limited", "Rate limited"), sentinel) {
+		t.Error("Expected a code-only sentinel not to match a different code")
+	}
+
+	marked := NewError(ExternalError, "quota_exceeded", "Quota exceeded").MatchByCodeOnly()
+	if errors.Is(marked, NewError(BusinessError, "quota_exceeded", "")) {
+		t.Error("Expected marking the inspected error to have no effect")
+	}
+
+	SetCaseInsensitiveCodes(true)
+	defer SetCaseInsensitiveCodes(false)
+
+	if !errors.Is(NewError(SystemError, "QUOTA_EXCEEDED", "Quota exceeded"), sentinel) {
+		t.Error("Expected code-only matching to honor case-insensitive codes")
+	}
+}
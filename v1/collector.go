@@ -0,0 +1,109 @@
+package errmgt
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// LineCode is the code of the errors LineCollector creates for lines when
+// no parse function is given
+const LineCode Code = "output_line"
+
+// LineNumberKey is the context key under which LineCollector records the
+// number of the line, counting from 1, each error was parsed from
+const LineNumberKey = "line_number"
+
+// LineCollector is an io.Writer that turns each line written to it into a
+// ManagedError, for bridging tools that report errors as text, such as a
+// subprocess's stderr:
+//
+//	collector := errmgt.NewLineCollector(nil)
+//	cmd.Stderr = collector
+//	runErr := cmd.Run()
+//	collector.Close()
+//	return errmgt.Append(runErr, collector.Err())
+//
+// Lines may be split across writes. A final line without a trailing
+// newline is only parsed by Close. It is safe for concurrent use.
+type LineCollector struct {
+	parse func(line string) *ManagedError
+
+	mu      sync.Mutex
+	partial []byte
+	lines   int
+	errs    []error
+}
+
+// NewLineCollector creates a LineCollector that converts lines with parse.
+// Lines for which parse returns nil are skipped, so parse can also filter.
+// A nil parse creates an ExternalError with code LineCode and the line as
+// its message. Blank lines are always skipped, and trailing carriage
+// returns are removed before parsing.
+func NewLineCollector(parse func(line string) *ManagedError) *LineCollector {
+	return &LineCollector{parse: parse}
+}
+
+// Write implements io.Writer. It never fails.
+func (c *LineCollector) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data := append(c.partial, p...)
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		c.collect(string(data[:i]))
+		data = data[i+1:]
+	}
+	c.partial = append([]byte(nil), data...)
+	return len(p), nil
+}
+
+// Close parses any final line that was not terminated by a newline. It
+// always returns nil.
+func (c *LineCollector) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.partial) > 0 {
+		c.collect(string(c.partial))
+		c.partial = nil
+	}
+	return nil
+}
+
+// Err returns the errors collected so far as a MultiError, in the order
+// their lines were written, or nil if there are none
+func (c *LineCollector) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.errs) == 0 {
+		return nil
+	}
+	return &MultiError{Errors: append([]error(nil), c.errs...)}
+}
+
+// collect parses a single line
+func (c *LineCollector) collect(line string) {
+	c.lines++
+	line = strings.TrimSuffix(line, "\r")
+	if strings.TrimSpace(line) == "" {
+		return
+	}
+
+	var err *ManagedError
+	if c.parse != nil {
+		err = c.parse(line)
+	} else {
+		err = NewError(ExternalError, LineCode, line)
+	}
+	if err == nil {
+		return
+	}
+	c.errs = append(c.errs, err.WithContext(LineNumberKey, strconv.Itoa(c.lines)))
+}
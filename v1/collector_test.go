@@ -0,0 +1,84 @@
+package errmgt
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLineCollector(t *testing.T) {
+	collector := NewLineCollector(nil)
+
+	fmt.Fprint(collector, "disk full\r\n\nper")
+	fmt.Fprint(collector, "mission denied\n")
+	fmt.Fprint(collector, "timeout")
+
+	err := collector.Err()
+	var multi *MultiError
+	if !errors.As(err, &multi) || len(multi.Errors) != 2 {
+		t.Fatalf("Expected 2 errors before Close, got %v", err)
+	}
+
+	if closeErr := collector.Close(); closeErr != nil {
+		t.Fatalf("Close() error: %v", closeErr)
+	}
+
+	if !errors.As(collector.Err(), &multi) || len(multi.Errors) != 3 {
+		t.Fatalf("Expected the final line after Close, got %v", collector.Err())
+	}
+
+	expected := []struct {
+		message string
+		line    string
+	}{
+		{"disk full", "1"},
+		{"permission denied", "3"},
+		{"timeout", "4"},
+	}
+
+	for i, want := range expected {
+		managedErr := multi.Errors[i].(*ManagedError)
+		if managedErr.Type != ExternalError || managedErr.Code != LineCode {
+			t.Errorf("Expected an external %s error, got %v", LineCode, managedErr)
+		}
+		if managedErr.Message != want.message {
+			t.Errorf("Expected message %q, got %q", want.message, managedErr.Message)
+		}
+		if managedErr.Context[LineNumberKey] != want.line {
+			t.Errorf("Expected line number %s, got %s", want.line, managedErr.Context[LineNumberKey])
+		}
+	}
+}
+
+func TestLineCollectorParse(t *testing.T) {
+	collector := NewLineCollector(func(line string) *ManagedError {
+		if !strings.HasPrefix(line, "ERROR ") {
+			return nil
+		}
+		return NewError(SystemError, "batch_failed", strings.TrimPrefix(line, "ERROR "))
+	})
+
+	_, _ = io.WriteString(collector, "INFO starting\nERROR row 7 invalid\nINFO done\n")
+
+	var multi *MultiError
+	if !errors.As(collector.Err(), &multi) || len(multi.Errors) != 1 {
+		t.Fatalf("Expected 1 error, got %v", collector.Err())
+	}
+
+	managedErr := multi.Errors[0].(*ManagedError)
+	if managedErr.Code != "batch_failed" || managedErr.Message != "row 7 invalid" || managedErr.Context[LineNumberKey] != "2" {
+		t.Errorf("Unexpected error: %+v", managedErr)
+	}
+}
+
+func TestLineCollectorEmpty(t *testing.T) {
+	collector := NewLineCollector(nil)
+	_, _ = io.WriteString(collector, "\n  \n")
+	_ = collector.Close()
+
+	if err := collector.Err(); err != nil {
+		t.Errorf("Expected nil for blank output, got %v", err)
+	}
+}
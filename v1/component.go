@@ -0,0 +1,53 @@
+package errmgt
+
+import "sync/atomic"
+
+var defaultComponent atomic.Value
+
+// SetDefaultComponent sets the Component given to errors created
+// afterwards, typically the owning module or team of a service. An empty
+// name, the default, leaves Component unset.
+func SetDefaultComponent(name string) {
+	defaultComponent.Store(name)
+}
+
+// currentDefaultComponent returns the name set with SetDefaultComponent
+func currentDefaultComponent() string {
+	name, _ := defaultComponent.Load().(string)
+	return name
+}
+
+// WithComponent sets the component or subsystem that owns the error, such
+// as "billing", for routing alerts to the responsible team
+func (e *ManagedError) WithComponent(name string) *ManagedError {
+	if e == nil {
+		return nil
+	}
+	e.Component = name
+	return e
+}
+
+// GetComponent retrieves the component from the first ManagedError in
+// err's chain. It returns an empty string if there is none.
+func GetComponent(err error) string {
+	if managedErr, ok := asManaged(err); ok {
+		return managedErr.Component
+	}
+	return ""
+}
+
+// GroupByComponent groups errs by GetComponent, preserving their order
+// within each group. Errors without a component, including errors that are
+// not ManagedErrors, are grouped under the empty string. Nil errors are
+// skipped.
+func GroupByComponent(errs []error) map[string][]error {
+	groups := make(map[string][]error)
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		component := GetComponent(err)
+		groups[component] = append(groups[component], err)
+	}
+	return groups
+}
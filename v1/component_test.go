@@ -0,0 +1,59 @@
+package errmgt
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestWithComponent(t *testing.T) {
+	err := NewError(SystemError, "ledger_unavailable", "Ledger unavailable").WithComponent("billing")
+
+	if err.Component != "billing" {
+		t.Errorf("Expected component 'billing', got '%s'", err.Component)
+	}
+
+	if got := GetComponent(fmt.Errorf("charge: %w", err)); got != "billing" {
+		t.Errorf("Expected component 'billing' through wrapping, got '%s'", got)
+	}
+
+	if got := GetComponent(errors.New("plain")); got != "" {
+		t.Errorf("Expected no component for a plain error, got '%s'", got)
+	}
+}
+
+func TestSetDefaultComponent(t *testing.T) {
+	SetDefaultComponent("checkout")
+	defer SetDefaultComponent("")
+
+	err := NewError(BusinessError, "cart_empty", "Cart is empty")
+	if err.Component != "checkout" {
+		t.Errorf("Expected default component 'checkout', got '%s'", err.Component)
+	}
+
+	if err.WithComponent("billing").Component != "billing" {
+		t.Errorf("Expected WithComponent to override the default, got '%s'", err.Component)
+	}
+}
+
+func TestGroupByComponent(t *testing.T) {
+	billing1 := NewError(SystemError, "ledger_unavailable", "Ledger unavailable").WithComponent("billing")
+	billing2 := NewError(ValidationError, "invalid_amount", "Invalid amount").WithComponent("billing")
+	search := NewError(ExternalError, "index_timeout", "Index timeout").WithComponent("search")
+	plain := errors.New("plain")
+
+	groups := GroupByComponent([]error{billing1, search, nil, plain, billing2})
+
+	if len(groups) != 3 {
+		t.Fatalf("Expected 3 groups, got %d: %v", len(groups), groups)
+	}
+	if len(groups["billing"]) != 2 || groups["billing"][0] != billing1 || groups["billing"][1] != billing2 {
+		t.Errorf("Unexpected billing group: %v", groups["billing"])
+	}
+	if len(groups["search"]) != 1 || groups["search"][0] != search {
+		t.Errorf("Unexpected search group: %v", groups["search"])
+	}
+	if len(groups[""]) != 1 || groups[""][0] != plain {
+		t.Errorf("Unexpected ungrouped errors: %v", groups[""])
+	}
+}
@@ -0,0 +1,68 @@
+package errmgt
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// RunConcurrent calls each of fns in its own goroutine with a context
+// derived from ctx and waits for all of them. Unlike errgroup it keeps
+// every failure: the non-nil results are returned as a MultiError ordered
+// by the position of their function in fns, regardless of which finished
+// first. It returns nil when every function succeeds.
+func RunConcurrent(ctx context.Context, fns ...func(context.Context) error) error {
+	return runConcurrent(ctx, false, fns)
+}
+
+// RunConcurrentFailFast is like RunConcurrent, but cancels the context
+// passed to the remaining functions as soon as one fails. Functions that
+// then return an error matching context.Canceled are left out of the
+// result, so it holds the failures rather than the cancellations they
+// caused; cancellations of ctx itself are still reported.
+func RunConcurrentFailFast(ctx context.Context, fns ...func(context.Context) error) error {
+	return runConcurrent(ctx, true, fns)
+}
+
+// runConcurrent implements RunConcurrent and RunConcurrentFailFast
+func runConcurrent(ctx context.Context, failFast bool, fns []func(context.Context) error) error {
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]error, len(fns))
+	var failedOnce sync.Once
+	first := -1
+
+	var wg sync.WaitGroup
+	for i, fn := range fns {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(runCtx); err != nil {
+				results[i] = err
+				if failFast {
+					failedOnce.Do(func() {
+						first = i
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	var errs []error
+	for i, err := range results {
+		if err == nil {
+			continue
+		}
+		if failFast && i != first && errors.Is(err, context.Canceled) && ctx.Err() == nil {
+			continue
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return &MultiError{Errors: errs}
+}
@@ -0,0 +1,113 @@
+package errmgt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunConcurrent(t *testing.T) {
+	slow := NewError(ExternalError, "api_timeout", "API timeout")
+	fast := NewError(SystemError, "db_error", "Database error")
+
+	err := RunConcurrent(context.Background(),
+		func(ctx context.Context) error {
+			time.Sleep(20 * time.Millisecond)
+			return slow
+		},
+		func(ctx context.Context) error { return nil },
+		func(ctx context.Context) error { return fast },
+	)
+
+	var multi *MultiError
+	if !errors.As(err, &multi) {
+		t.Fatalf("Expected a MultiError, got %T", err)
+	}
+
+	if len(multi.Errors) != 2 || multi.Errors[0] != slow || multi.Errors[1] != fast {
+		t.Errorf("Expected errors in function order, got %v", multi.Errors)
+	}
+}
+
+func TestRunConcurrentSuccess(t *testing.T) {
+	ran := make(chan struct{}, 2)
+	err := RunConcurrent(context.Background(),
+		func(ctx context.Context) error { ran <- struct{}{}; return nil },
+		func(ctx context.Context) error { ran <- struct{}{}; return nil },
+	)
+
+	if err != nil {
+		t.Errorf("Expected nil when every function succeeds, got %v", err)
+	}
+
+	if len(ran) != 2 {
+		t.Errorf("Expected both functions to run, got %d", len(ran))
+	}
+
+	if err := RunConcurrent(context.Background()); err != nil {
+		t.Errorf("Expected nil without functions, got %v", err)
+	}
+}
+
+func TestRunConcurrentDoesNotCancel(t *testing.T) {
+	err := RunConcurrent(context.Background(),
+		func(ctx context.Context) error { return errors.New("failed") },
+		func(ctx context.Context) error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(20 * time.Millisecond):
+				return nil
+			}
+		},
+	)
+
+	if errors.Is(err, context.Canceled) {
+		t.Errorf("Expected RunConcurrent not to cancel the other functions, got %v", err)
+	}
+}
+
+func TestRunConcurrentFailFast(t *testing.T) {
+	failure := NewError(SystemError, "db_error", "Database error")
+
+	err := RunConcurrentFailFast(context.Background(),
+		func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+		func(ctx context.Context) error { return failure },
+		func(ctx context.Context) error {
+			<-ctx.Done()
+			return Wrap(ctx.Err(), "waiting")
+		},
+	)
+
+	var multi *MultiError
+	if !errors.As(err, &multi) {
+		t.Fatalf("Expected a MultiError, got %T", err)
+	}
+
+	if len(multi.Errors) != 1 || multi.Errors[0] != failure {
+		t.Errorf("Expected only the failure, got %v", multi.Errors)
+	}
+}
+
+func TestRunConcurrentFailFastParentCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := RunConcurrentFailFast(ctx,
+		func(ctx context.Context) error { return ctx.Err() },
+		func(ctx context.Context) error { return ctx.Err() },
+	)
+
+	var multi *MultiError
+	if !errors.As(err, &multi) || len(multi.Errors) != 2 {
+		t.Fatalf("Expected both cancellations of the parent context, got %v", err)
+	}
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
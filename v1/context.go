@@ -0,0 +1,226 @@
+package errmgt
+
+import (
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// ContextTruncatedKey is set in an error's context when WithContext drops
+// keys because the context has reached the MaxContextKeys limit.
+const ContextTruncatedKey = "context_truncated"
+
+// NamespaceDelimiter separates a namespace from a key in the context keys
+// written by WithNamespacedContext
+const NamespaceDelimiter = "."
+
+var maxContextKeys atomic.Int64
+
+// globalContext holds the entries set with SetGlobalContext and their keys
+// in sorted order
+type globalContext struct {
+	entries map[string]string
+	keys    []string
+}
+
+var globalContextEntries atomic.Pointer[globalContext]
+
+// SetMaxContextKeys limits how many keys WithContext stores on a single
+// error. Once the limit is reached, new keys are dropped (existing keys may
+// still be updated) and ContextTruncatedKey is recorded, so the first keys
+// added are the ones kept. Neither the marker nor the entries set with
+// SetGlobalContext count towards the limit.
+// A value of zero or less disables the limit, which is the default.
+func SetMaxContextKeys(n int) {
+	maxContextKeys.Store(int64(n))
+}
+
+// MaxContextKeys returns the limit configured with SetMaxContextKeys
+func MaxContextKeys() int {
+	return int(maxContextKeys.Load())
+}
+
+// contextFull reports whether context has reached the MaxContextKeys
+// limit, not counting the truncation marker and global context keys
+func contextFull(context map[string]string) bool {
+	limit := maxContextKeys.Load()
+	if limit <= 0 {
+		return false
+	}
+	n := len(context)
+	if _, truncated := context[ContextTruncatedKey]; truncated {
+		n--
+	}
+	if global := globalContextEntries.Load(); global != nil {
+		for _, key := range global.keys {
+			if _, exists := context[key]; exists {
+				n--
+			}
+		}
+	}
+	return int64(n) >= limit
+}
+
+// SetGlobalContext sets context entries, such as the deployment
+// environment and service version, that are added to every error created
+// afterwards. Per-error context set with WithContext takes precedence. The
+// map is copied, so later changes to it have no effect; call
+// SetGlobalContext again to replace the entries. Passing nil or an empty
+// map removes them.
+func SetGlobalContext(context map[string]string) {
+	if len(context) == 0 {
+		globalContextEntries.Store(nil)
+		return
+	}
+	global := &globalContext{
+		entries: make(map[string]string, len(context)),
+		keys:    make([]string, 0, len(context)),
+	}
+	for key, value := range context {
+		global.entries[key] = value
+		global.keys = append(global.keys, key)
+	}
+	sort.Strings(global.keys)
+	globalContextEntries.Store(global)
+}
+
+// addGlobalContext adds the global context to the error without
+// overwriting keys that are already set. The entries bypass WithContext,
+// so they are never truncated by the MaxContextKeys limit.
+func (e *ManagedError) addGlobalContext() {
+	global := globalContextEntries.Load()
+	if global == nil {
+		return
+	}
+	if e.Context == nil {
+		e.Context = make(map[string]string, len(global.keys))
+	}
+	for _, key := range global.keys {
+		if _, exists := e.Context[key]; !exists {
+			e.Context[key] = global.entries[key]
+		}
+	}
+}
+
+// WithContextIf adds context information to the error only when cond is true
+func (e *ManagedError) WithContextIf(cond bool, key, value string) *ManagedError {
+	if cond {
+		e.WithContext(key, value)
+	}
+	return e
+}
+
+// WithContextNonEmpty adds context information to the error only when value
+// is not empty
+func (e *ManagedError) WithContextNonEmpty(key, value string) *ManagedError {
+	return e.WithContextIf(value != "", key, value)
+}
+
+// WithNamespacedContext adds context information under the key
+// namespace + NamespaceDelimiter + key, so that layers enriching the same
+// error do not overwrite each other's keys. No escaping is performed: a
+// namespace containing the delimiter, such as "db.primary", nests inside
+// the namespace before it ("db"), and keys are stored verbatim.
+func (e *ManagedError) WithNamespacedContext(namespace, key, value string) *ManagedError {
+	return e.WithContext(namespace+NamespaceDelimiter+key, value)
+}
+
+// GetNamespace returns the context entries of err stored under namespace,
+// with the namespace and delimiter stripped from their keys. Entries of
+// nested namespaces are included with the nested namespace still in the
+// key. It returns nil when err is not a ManagedError or has no entries in
+// the namespace.
+func GetNamespace(err error, namespace string) map[string]string {
+	prefix := namespace + NamespaceDelimiter
+	var entries map[string]string
+	for key, value := range GetContext(err) {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if entries == nil {
+			entries = make(map[string]string)
+		}
+		entries[strings.TrimPrefix(key, prefix)] = value
+	}
+	return entries
+}
+
+// WithContextTime adds a time to the error's context, formatted as RFC 3339
+// with sub-second precision
+func (e *ManagedError) WithContextTime(key string, t time.Time) *ManagedError {
+	return e.WithContext(key, t.Format(time.RFC3339Nano))
+}
+
+// WithContextDuration adds a duration to the error's context, formatted by
+// time.Duration.String
+func (e *ManagedError) WithContextDuration(key string, d time.Duration) *ManagedError {
+	return e.WithContext(key, d.String())
+}
+
+// GetContextTime retrieves a time stored with WithContextTime. The boolean
+// is false when err is not a ManagedError, the key is missing or its value
+// is not an RFC 3339 time.
+func GetContextTime(err error, key string) (time.Time, bool) {
+	value, exists := GetContext(err)[key]
+	if !exists {
+		return time.Time{}, false
+	}
+	t, parseErr := time.Parse(time.RFC3339, value)
+	if parseErr != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// GetContextDuration retrieves a duration stored with WithContextDuration.
+// The boolean is false when err is not a ManagedError, the key is missing
+// or its value is not a duration.
+func GetContextDuration(err error, key string) (time.Duration, bool) {
+	value, exists := GetContext(err)[key]
+	if !exists {
+		return 0, false
+	}
+	d, parseErr := time.ParseDuration(value)
+	if parseErr != nil {
+		return 0, false
+	}
+	return d, true
+}
+
+// WithContextFrom merges the context of another ManagedError into the
+// error, without overwriting keys that are already set. Keys are merged in
+// sorted order, so the result is deterministic when MaxContextKeys applies.
+// It does nothing if other is not a ManagedError.
+func (e *ManagedError) WithContextFrom(other error) *ManagedError {
+	if e == nil {
+		return nil
+	}
+	context := GetContext(other)
+	keys := make([]string, 0, len(context))
+	for key := range context {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if _, exists := e.Context[key]; !exists {
+			e.WithContext(key, context[key])
+		}
+	}
+	return e
+}
+
+// WithoutContext returns a copy of the error with no context, including
+// global context, for uses such as metric labels where only the error's
+// structural identity matters. Unlike Sanitized, every other field,
+// including the cause and details, is kept. The original error is not
+// modified. A nil error returns nil.
+func (e *ManagedError) WithoutContext() *ManagedError {
+	if e == nil {
+		return nil
+	}
+	c := e.clone()
+	c.Context = nil
+	return c
+}
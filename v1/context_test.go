@@ -0,0 +1,310 @@
+package errmgt
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSetMaxContextKeys(t *testing.T) {
+	SetMaxContextKeys(2)
+	defer SetMaxContextKeys(0)
+
+	if MaxContextKeys() != 2 {
+		t.Errorf("Expected max context keys 2, got %d", MaxContextKeys())
+	}
+
+	err := NewError(SystemError, "db_error", "Database error").
+		WithContext("first", "1").
+		WithContext("second", "2").
+		WithContext("third", "3").
+		WithContext("fourth", "4")
+
+	if err.Context["first"] != "1" || err.Context["second"] != "2" {
+		t.Error("Expected the first keys to be kept")
+	}
+
+	if _, exists := err.Context["third"]; exists {
+		t.Error("Expected keys beyond the limit to be dropped")
+	}
+
+	if err.Context[ContextTruncatedKey] != "true" {
+		t.Error("Expected truncation marker to be set")
+	}
+
+	// Existing keys can still be updated
+	err.WithContext("first", "updated")
+	if err.Context["first"] != "updated" {
+		t.Error("Expected existing key to be updated")
+	}
+
+	if len(err.Context) != 3 {
+		t.Errorf("Expected 3 context entries, got %d", len(err.Context))
+	}
+}
+
+func TestMaxContextKeysUnlimitedByDefault(t *testing.T) {
+	err := NewError(SystemError, "db_error", "Database error")
+	for _, key := range []string{"a", "b", "c", "d", "e"} {
+		err.WithContext(key, key)
+	}
+
+	if len(err.Context) != 5 {
+		t.Errorf("Expected 5 context entries, got %d", len(err.Context))
+	}
+
+	if _, exists := err.Context[ContextTruncatedKey]; exists {
+		t.Error("Expected no truncation marker without a limit")
+	}
+}
+
+func TestManagedErrorWithContextIf(t *testing.T) {
+	err := NewError(ValidationError, "invalid_input", "Invalid input").
+		WithContextIf(true, "included", "yes").
+		WithContextIf(false, "excluded", "no")
+
+	if err.Context["included"] != "yes" {
+		t.Error("Expected included context to be set")
+	}
+
+	if _, exists := err.Context["excluded"]; exists {
+		t.Error("Expected excluded context not to be set")
+	}
+}
+
+func TestManagedErrorWithContextNonEmpty(t *testing.T) {
+	err := NewError(ValidationError, "invalid_input", "Invalid input").
+		WithContextNonEmpty("field", "email").
+		WithContextNonEmpty("user_id", "")
+
+	if err.Context["field"] != "email" {
+		t.Error("Expected field context to be set")
+	}
+
+	if _, exists := err.Context["user_id"]; exists {
+		t.Error("Expected empty user_id context not to be set")
+	}
+}
+
+func TestManagedErrorWithContextFrom(t *testing.T) {
+	low := NewError(SystemError, "db_error", "Database error").
+		WithContext("table", "users").
+		WithContext("operation", "select")
+
+	err := NewErrorWithCause(BusinessError, "user_lookup_failed", "User lookup failed", low).
+		WithContext("operation", "get_user").
+		WithContextFrom(low)
+
+	if err.Context["table"] != "users" {
+		t.Error("Expected table context to be copied")
+	}
+
+	if err.Context["operation"] != "get_user" {
+		t.Error("Expected existing operation context not to be overwritten")
+	}
+
+	if len(low.Context) != 2 {
+		t.Error("Expected the source context to be unchanged")
+	}
+}
+
+func TestManagedErrorWithContextFromNonManaged(t *testing.T) {
+	err := NewError(BusinessError, "rule", "Rule violated").
+		WithContextFrom(errors.New("plain error")).
+		WithContextFrom(nil)
+
+	if len(err.Context) != 0 {
+		t.Errorf("Expected no context, got %v", err.Context)
+	}
+}
+
+func TestManagedErrorWithContextTime(t *testing.T) {
+	deadline := time.Date(2024, 3, 1, 12, 30, 0, 500, time.FixedZone("CET", 3600))
+	err := NewError(ExternalError, "timeout", "Timed out").WithContextTime("deadline", deadline)
+
+	if err.Context["deadline"] != "2024-03-01T12:30:00.0000005+01:00" {
+		t.Errorf("Expected RFC 3339 time in context, got '%s'", err.Context["deadline"])
+	}
+
+	got, ok := GetContextTime(fmt.Errorf("wrapped: %w", err), "deadline")
+	if !ok || !got.Equal(deadline) {
+		t.Errorf("GetContextTime() = %v, %v, want %v, true", got, ok, deadline)
+	}
+}
+
+func TestManagedErrorWithContextDuration(t *testing.T) {
+	err := NewError(ExternalError, "timeout", "Timed out").WithContextDuration("elapsed", 1500*time.Millisecond)
+
+	if err.Context["elapsed"] != "1.5s" {
+		t.Errorf("Expected '1.5s' in context, got '%s'", err.Context["elapsed"])
+	}
+
+	got, ok := GetContextDuration(err, "elapsed")
+	if !ok || got != 1500*time.Millisecond {
+		t.Errorf("GetContextDuration() = %v, %v, want 1.5s, true", got, ok)
+	}
+}
+
+func TestGetContextTypedInvalid(t *testing.T) {
+	err := NewError(ValidationError, "invalid", "Invalid").WithContext("value", "soon")
+
+	if _, ok := GetContextTime(err, "value"); ok {
+		t.Error("Expected GetContextTime to reject a malformed time")
+	}
+
+	if _, ok := GetContextDuration(err, "value"); ok {
+		t.Error("Expected GetContextDuration to reject a malformed duration")
+	}
+
+	if _, ok := GetContextTime(err, "missing"); ok {
+		t.Error("Expected GetContextTime to report a missing key")
+	}
+
+	if _, ok := GetContextDuration(errors.New("plain"), "value"); ok {
+		t.Error("Expected GetContextDuration to report a non-managed error")
+	}
+}
+
+func TestManagedErrorWithNamespacedContext(t *testing.T) {
+	err := NewError(SystemError, "sync_failed", "Sync failed").
+		WithNamespacedContext("db", "id", "7").
+		WithNamespacedContext("http", "id", "req-123").
+		WithNamespacedContext("db.primary", "host", "db1").
+		WithContext("dbx", "unrelated")
+
+	if err.Context["db.id"] != "7" || err.Context["http.id"] != "req-123" {
+		t.Errorf("Expected namespaced keys in a flat context, got %v", err.Context)
+	}
+
+	db := GetNamespace(fmt.Errorf("wrapped: %w", err), "db")
+	expected := map[string]string{"id": "7", "primary.host": "db1"}
+	if len(db) != len(expected) {
+		t.Fatalf("GetNamespace(db) = %v, want %v", db, expected)
+	}
+	for key, value := range expected {
+		if db[key] != value {
+			t.Errorf("GetNamespace(db)[%s] = '%s', want '%s'", key, db[key], value)
+		}
+	}
+
+	if primary := GetNamespace(err, "db.primary"); len(primary) != 1 || primary["host"] != "db1" {
+		t.Errorf("GetNamespace(db.primary) = %v, want map[host:db1]", primary)
+	}
+
+	if missing := GetNamespace(err, "cache"); missing != nil {
+		t.Errorf("Expected nil for an unused namespace, got %v", missing)
+	}
+
+	if plain := GetNamespace(errors.New("plain"), "db"); plain != nil {
+		t.Errorf("Expected nil for a non-managed error, got %v", plain)
+	}
+}
+
+func TestSetGlobalContext(t *testing.T) {
+	global := map[string]string{"env": "staging", "version": "1.4.2"}
+	SetGlobalContext(global)
+	defer SetGlobalContext(nil)
+
+	global["env"] = "changed"
+
+	err := NewError(SystemError, "db_error", "Database error").WithContext("version", "override")
+
+	if err.Context["env"] != "staging" {
+		t.Errorf("Expected global context to be copied, got '%s'", err.Context["env"])
+	}
+
+	if err.Context["version"] != "override" {
+		t.Errorf("Expected per-error context to take precedence, got '%s'", err.Context["version"])
+	}
+
+	SetGlobalContext(nil)
+	if err := NewError(SystemError, "db_error", "Database error"); err.Context != nil {
+		t.Errorf("Expected no context after clearing the global context, got %v", err.Context)
+	}
+}
+
+func TestSetGlobalContextMaxContextKeys(t *testing.T) {
+	SetGlobalContext(map[string]string{"env": "staging", "region": "eu-1", "version": "1.4.2"})
+	defer SetGlobalContext(nil)
+	SetMaxContextKeys(2)
+	defer SetMaxContextKeys(0)
+
+	err := NewError(SystemError, "db_error", "Database error").
+		WithContext("table", "users").
+		WithContext("op", "insert")
+
+	for _, key := range []string{"env", "region", "version", "table", "op"} {
+		if _, ok := err.Context[key]; !ok {
+			t.Errorf("Expected context key '%s' to be kept, got %v", key, err.Context)
+		}
+	}
+
+	if _, truncated := err.Context[ContextTruncatedKey]; truncated {
+		t.Errorf("Expected global context not to count towards the limit, got %v", err.Context)
+	}
+
+	err.WithContext("user_id", "42")
+	if _, truncated := err.Context[ContextTruncatedKey]; !truncated {
+		t.Errorf("Expected per-error keys past the limit to be dropped, got %v", err.Context)
+	}
+}
+
+func TestSetGlobalContextConcurrent(t *testing.T) {
+	defer SetGlobalContext(nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			SetGlobalContext(map[string]string{"env": "prod", "worker": fmt.Sprint(i)})
+		}(i)
+		go func() {
+			defer wg.Done()
+			err := NewError(SystemError, "db_error", "Database error")
+			if len(err.Context) != 0 && len(err.Context) != 2 {
+				t.Errorf("Expected a consistent snapshot, got %v", err.Context)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestManagedErrorWithoutContext(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := NewErrorWithCause(SystemError, "db_error", "Database error", cause).
+		WithDetails("primary unavailable").
+		WithContext("user_id", "42").
+		WithContext("query", "SELECT 1").
+		WithTags("storage")
+
+	stripped := err.WithoutContext()
+
+	if stripped == err {
+		t.Fatal("Expected a copy")
+	}
+
+	if len(stripped.Context) != 0 || GetContext(stripped) != nil {
+		t.Errorf("Expected no context, got %v", stripped.Context)
+	}
+
+	if stripped.GetID() != err.GetID() || stripped.Code != err.Code || stripped.Details != err.Details || stripped.Cause != cause || !HasTag(stripped, "storage") {
+		t.Errorf("Expected every other field to be kept, got %+v", stripped)
+	}
+
+	if len(err.Context) != 2 {
+		t.Errorf("Expected the original context to be unchanged, got %v", err.Context)
+	}
+
+	stripped.WithContext("region", "eu-1")
+	if _, exists := err.Context["region"]; exists {
+		t.Error("Expected the copy not to share a context map with the original")
+	}
+
+	var nilErr *ManagedError
+	if nilErr.WithoutContext() != nil {
+		t.Error("Expected nil for a nil error")
+	}
+}
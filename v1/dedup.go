@@ -0,0 +1,73 @@
+package errmgt
+
+import (
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+// Deduplicator suppresses repeated errors within a time window. Errors are
+// considered duplicates when they have the same Normalize signature: the
+// same type and code, or for errors without a code, the same message once
+// identifiers are stripped. It is safe for concurrent use.
+type Deduplicator struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	seen      map[uint64]time.Time
+	lastSweep time.Time
+}
+
+// NewDeduplicator creates a Deduplicator that suppresses duplicates for
+// window after an error is first seen
+func NewDeduplicator(window time.Duration) *Deduplicator {
+	return &Deduplicator{
+		window: window,
+		now:    currentTime,
+		seen:   make(map[uint64]time.Time),
+	}
+}
+
+// Seen reports whether an equivalent error was already seen within the
+// window. It returns false the first time an error is seen, after which
+// duplicates return true until the window expires. Seen returns false for
+// a nil error.
+func (d *Deduplicator) Seen(err error) bool {
+	if err == nil {
+		return false
+	}
+	key := dedupKey(err)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	d.sweep(now)
+
+	if first, ok := d.seen[key]; ok && now.Sub(first) < d.window {
+		return true
+	}
+	d.seen[key] = now
+	return false
+}
+
+// sweep evicts expired entries, at most once per window
+func (d *Deduplicator) sweep(now time.Time) {
+	if now.Sub(d.lastSweep) < d.window {
+		return
+	}
+	for key, first := range d.seen {
+		if now.Sub(first) >= d.window {
+			delete(d.seen, key)
+		}
+	}
+	d.lastSweep = now
+}
+
+// dedupKey hashes the normalized signature of the error
+func dedupKey(err error) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(Normalize(err)))
+	return h.Sum64()
+}
@@ -0,0 +1,103 @@
+package errmgt
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestDeduplicator(window time.Duration) (*Deduplicator, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	d := NewDeduplicator(window)
+	d.now = func() time.Time { return now }
+	return d, &now
+}
+
+func TestDeduplicatorSeen(t *testing.T) {
+	d, now := newTestDeduplicator(time.Minute)
+
+	err := NewError(ExternalError, "api_timeout", "API timeout")
+	if d.Seen(err) {
+		t.Error("Expected first occurrence not to be seen")
+	}
+
+	duplicate := NewError(ExternalError, "api_timeout", "Different message")
+	if !d.Seen(duplicate) {
+		t.Error("Expected duplicate with same type and code to be seen")
+	}
+
+	if d.Seen(NewError(ExternalError, "api_unavailable", "API unavailable")) {
+		t.Error("Expected error with a different code not to be seen")
+	}
+
+	if d.Seen(NewError(SystemError, "api_timeout", "API timeout")) {
+		t.Error("Expected error with a different type not to be seen")
+	}
+
+	*now = now.Add(time.Minute)
+	if d.Seen(err) {
+		t.Error("Expected error not to be seen after the window expired")
+	}
+	if !d.Seen(err) {
+		t.Error("Expected error to be seen again within the new window")
+	}
+}
+
+func TestDeduplicatorNonManaged(t *testing.T) {
+	d, _ := newTestDeduplicator(time.Minute)
+
+	if d.Seen(nil) {
+		t.Error("Expected nil not to be seen")
+	}
+
+	if d.Seen(errors.New("connection refused")) {
+		t.Error("Expected first occurrence not to be seen")
+	}
+
+	if !d.Seen(errors.New("connection refused")) {
+		t.Error("Expected error with the same message to be seen")
+	}
+
+	d.Seen(NewError(ExternalError, "api_timeout", "API timeout"))
+	if !d.Seen(Wrap(NewError(ExternalError, "api_timeout", "API timeout"), "calling API")) {
+		t.Error("Expected wrapped ManagedError to be keyed by type and code")
+	}
+}
+
+func TestDeduplicatorEvicts(t *testing.T) {
+	d, now := newTestDeduplicator(time.Minute)
+
+	d.Seen(NewError(ExternalError, "api_timeout", "API timeout"))
+	*now = now.Add(2 * time.Minute)
+	d.Seen(NewError(ExternalError, "api_unavailable", "API unavailable"))
+
+	if len(d.seen) != 1 {
+		t.Errorf("Expected expired entries to be evicted, got %d entries", len(d.seen))
+	}
+}
+
+func TestDeduplicatorConcurrent(t *testing.T) {
+	d := NewDeduplicator(time.Hour)
+	err := NewError(ExternalError, "api_timeout", "API timeout")
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	firsts := 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if !d.Seen(err) {
+				mu.Lock()
+				firsts++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firsts != 1 {
+		t.Errorf("Expected exactly one first occurrence, got %d", firsts)
+	}
+}
@@ -0,0 +1,104 @@
+package errmgt
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Diff compares the chains of a and b level by level, following
+// errors.Unwrap from the outermost error, which is level 1, and returns a
+// human-readable description of each difference, such as
+//
+//	level 1: code differs: "invalid_email" != "invalid_name"
+//	level 3: extra cause in a: connection refused
+//
+// ManagedErrors are compared by type, code, message, details, status
+// code, retryability and context; other errors by their Go type and
+// message. IDs, timestamps and stack traces are ignored. Diff returns nil
+// when the chains match.
+func Diff(a, b error) []string {
+	chainA, chainB := chainOf(a), chainOf(b)
+
+	var diffs []string
+	for i := 0; i < len(chainA) || i < len(chainB); i++ {
+		level := i + 1
+		switch {
+		case i >= len(chainB):
+			diffs = append(diffs, fmt.Sprintf("level %d: extra cause in a: %v", level, chainA[i]))
+		case i >= len(chainA):
+			diffs = append(diffs, fmt.Sprintf("level %d: extra cause in b: %v", level, chainB[i]))
+		default:
+			for _, diff := range diffErrors(chainA[i], chainB[i]) {
+				diffs = append(diffs, fmt.Sprintf("level %d: %s", level, diff))
+			}
+		}
+	}
+	return diffs
+}
+
+// chainOf returns the errors in err's chain, outermost first
+func chainOf(err error) []error {
+	var chain []error
+	walkChain(err, func(e error) { chain = append(chain, e) })
+	return chain
+}
+
+// diffErrors describes how a and b differ, ignoring their causes
+func diffErrors(a, b error) []string {
+	managedA, okA := a.(*ManagedError)
+	managedB, okB := b.(*ManagedError)
+	if !okA || !okB || managedA == nil || managedB == nil {
+		var diffs []string
+		if typeA, typeB := reflect.TypeOf(a), reflect.TypeOf(b); typeA != typeB {
+			diffs = append(diffs, fmt.Sprintf("error type differs: %v != %v", typeA, typeB))
+		}
+		if a.Error() != b.Error() {
+			diffs = append(diffs, fmt.Sprintf("message differs: %q != %q", a.Error(), b.Error()))
+		}
+		return diffs
+	}
+
+	var diffs []string
+	field := func(name string, valueA, valueB interface{}) {
+		if valueA != valueB {
+			diffs = append(diffs, fmt.Sprintf("%s differs: %#v != %#v", name, valueA, valueB))
+		}
+	}
+	field("type", string(managedA.Type), string(managedB.Type))
+	field("code", string(managedA.Code), string(managedB.Code))
+	field("message", managedA.GetMessage(), managedB.GetMessage())
+	field("details", managedA.Details, managedB.Details)
+	field("status code", managedA.StatusCode, managedB.StatusCode)
+	field("retryable", managedA.Retryable, managedB.Retryable)
+	return append(diffs, diffContext(managedA.Context, managedB.Context)...)
+}
+
+// diffContext describes how two context maps differ, in key order
+func diffContext(a, b map[string]string) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, exists := a[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var diffs []string
+	for _, key := range keys {
+		valueA, inA := a[key]
+		valueB, inB := b[key]
+		switch {
+		case !inB:
+			diffs = append(diffs, fmt.Sprintf("context %q only in a: %q", key, valueA))
+		case !inA:
+			diffs = append(diffs, fmt.Sprintf("context %q only in b: %q", key, valueB))
+		case valueA != valueB:
+			diffs = append(diffs, fmt.Sprintf("context %q differs: %q != %q", key, valueA, valueB))
+		}
+	}
+	return diffs
+}
@@ -0,0 +1,68 @@
+package errmgt
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	a := NewErrorWithCause(ValidationError, "invalid_email", "Invalid email",
+		NewErrorWithCause(SystemError, "db_error", "Database error",
+			errors.New("connection refused"))).
+		WithContext("field", "email").
+		WithContext("attempt", "1")
+	b := NewErrorWithCause(BusinessError, "invalid_email", "Invalid email",
+		NewError(SystemError, "db_error", "Database failure").WithStatusCode(503)).
+		WithContext("field", "name").
+		WithContext("user_id", "42")
+
+	expected := []string{
+		`level 1: type differs: "validation" != "business"`,
+		`level 1: context "attempt" only in a: "1"`,
+		`level 1: context "field" differs: "email" != "name"`,
+		`level 1: context "user_id" only in b: "42"`,
+		`level 2: message differs: "Database error" != "Database failure"`,
+		`level 2: status code differs: 0 != 503`,
+		`level 3: extra cause in a: connection refused`,
+	}
+
+	if got := Diff(a, b); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Diff() =\n%q\nwant\n%q", got, expected)
+	}
+}
+
+func TestDiffPlainErrors(t *testing.T) {
+	a := fmt.Errorf("load: %w", errors.New("not found"))
+	b := fmt.Errorf("load: %w", NewError(BusinessError, "not_found", "not found"))
+
+	expected := []string{
+		`level 1: message differs: "load: not found" != "load: [business:not_found] not found"`,
+		`level 2: error type differs: *errors.errorString != *errmgt.ManagedError`,
+		`level 2: message differs: "not found" != "[business:not_found] not found"`,
+	}
+
+	if got := Diff(a, b); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Diff() =\n%q\nwant\n%q", got, expected)
+	}
+}
+
+func TestDiffEqual(t *testing.T) {
+	newErr := func() error {
+		return NewErrorWithCause(SystemError, "db_error", "Database error", errors.New("connection refused")).
+			WithContext("table", "users")
+	}
+
+	if diffs := Diff(newErr(), newErr()); diffs != nil {
+		t.Errorf("Expected no differences, got %q", diffs)
+	}
+
+	if diffs := Diff(nil, nil); diffs != nil {
+		t.Errorf("Expected no differences for nil, got %q", diffs)
+	}
+
+	if diffs := Diff(nil, errors.New("boom")); !reflect.DeepEqual(diffs, []string{"level 1: extra cause in b: boom"}) {
+		t.Errorf("Unexpected differences against nil: %q", diffs)
+	}
+}
@@ -0,0 +1,20 @@
+package errmgt
+
+// InternalErrorCode is the code Ensure gives to errors that are not
+// ManagedErrors
+const InternalErrorCode Code = "internal_error"
+
+// Ensure returns err as a ManagedError. If err's chain already contains a
+// ManagedError, the first one found is returned unchanged. Any other error
+// is wrapped in a SystemError with code InternalErrorCode, using err's
+// message and keeping err as the cause. Ensure returns nil for a nil
+// error, including a nil *ManagedError.
+func Ensure(err error) *ManagedError {
+	if managedErr, ok := err.(*ManagedError); err == nil || ok && managedErr == nil {
+		return nil
+	}
+	if managedErr, ok := asManaged(err); ok {
+		return managedErr
+	}
+	return newError(1, SystemError, InternalErrorCode, err.Error(), err)
+}
@@ -0,0 +1,51 @@
+package errmgt
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestEnsure(t *testing.T) {
+	managedErr := NewError(ValidationError, "invalid_input", "Invalid input")
+
+	if got := Ensure(managedErr); got != managedErr {
+		t.Errorf("Expected ManagedError to be returned unchanged, got %v", got)
+	}
+
+	if got := Ensure(fmt.Errorf("handler: %w", managedErr)); got != managedErr {
+		t.Errorf("Expected wrapped ManagedError to be found, got %v", got)
+	}
+}
+
+func TestEnsurePlainError(t *testing.T) {
+	plain := errors.New("disk full")
+	got := Ensure(plain)
+
+	if got.Type != SystemError {
+		t.Errorf("Expected type %s, got %s", SystemError, got.Type)
+	}
+
+	if got.Code != InternalErrorCode {
+		t.Errorf("Expected code '%s', got '%s'", InternalErrorCode, got.Code)
+	}
+
+	if got.Message != "disk full" {
+		t.Errorf("Expected message 'disk full', got '%s'", got.Message)
+	}
+
+	if !errors.Is(got, plain) {
+		t.Error("Expected the original error to be kept as the cause")
+	}
+}
+
+func TestEnsureNil(t *testing.T) {
+	if got := Ensure(nil); got != nil {
+		t.Errorf("Expected nil, got %v", got)
+	}
+
+	var typedNil *ManagedError
+	if got := Ensure(typedNil); got != nil {
+		t.Errorf("Expected nil for a typed nil, got %v", got)
+	}
+}
@@ -4,6 +4,8 @@ package errmgt
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 )
 
 // ErrorType represents different categories of errors
@@ -20,106 +22,336 @@ const (
 	ExternalError ErrorType = "external"
 )
 
+// Code is a machine-readable error code. Declaring codes as typed
+// constants makes the set of valid codes discoverable at compile time:
+//
+//	const (
+//		InvalidEmail errmgt.Code = "invalid_email"
+//		UserNotFound errmgt.Code = "user_not_found"
+//	)
+type Code string
+
+// String returns the code as a plain string
+func (c Code) String() string {
+	return string(c)
+}
+
 // ManagedError is a structured error with additional context
+//
+// The builder methods, such as WithContext, MarkBoundary and Observe, do
+// nothing on a nil *ManagedError and return nil, so building on an error
+// that may be nil does not panic.
 type ManagedError struct {
-	Code       string            `json:"code"`
-	Message    string            `json:"message"`
-	Details    string            `json:"details,omitempty"`
-	Cause      error             `json:"-"`
-	Context    map[string]string `json:"context,omitempty"`
-	Type       ErrorType         `json:"type"`
-	StatusCode int               `json:"status_code,omitempty"`
-	Retryable  bool              `json:"retryable"`
+	ID              string            `json:"id,omitempty"`
+	Code            Code              `json:"code"`
+	Message         string            `json:"message"`
+	Details         string            `json:"details,omitempty"`
+	UserMessage     string            `json:"user_message,omitempty"`
+	Cause           error             `json:"-"`
+	Context         map[string]string `json:"context,omitempty"`
+	Type            ErrorType         `json:"type"`
+	StatusCode      int               `json:"status_code,omitempty"`
+	Retryable       bool              `json:"retryable"`
+	RetryAfter      time.Duration     `json:"retry_after,omitempty"`
+	Span            *Span             `json:"span,omitempty"`
+	Severity        Severity          `json:"severity,omitempty"`
+	Tags            []string          `json:"tags,omitempty"`
+	Caller          string            `json:"caller,omitempty"`
+	Occurrences     int               `json:"occurrences,omitempty"`
+	HelpURL         string            `json:"help_url,omitempty"`
+	Metadata        interface{}       `json:"metadata,omitempty"`
+	Fingerprint     []string          `json:"fingerprint,omitempty"`
+	SuggestedAction string            `json:"suggested_action,omitempty"`
+	Boundary        bool              `json:"boundary,omitempty"`
+	Dependency      string            `json:"dependency,omitempty"`
+	Priority        int               `json:"priority,omitempty"`
+	ResourceType    string            `json:"resource_type,omitempty"`
+	ResourceID      string            `json:"resource_id,omitempty"`
+	Attempt         int               `json:"attempt,omitempty"`
+	Component       string            `json:"component,omitempty"`
+	ExpiresAt       time.Time         `json:"expires_at,omitzero"`
+	Timestamp       time.Time         `json:"timestamp,omitzero"`
+	ObservedAt      time.Time         `json:"observed_at,omitzero"`
+	ContainsPII     bool              `json:"contains_pii,omitempty"`
+	Scope           string            `json:"scope,omitempty"`
+	Transient       bool              `json:"transient,omitempty"`
+	GoroutineID     uint64            `json:"goroutine_id,omitempty"`
+
+	stack             []uintptr
+	publicContextKeys map[string]bool
+	detailLines       []string
+	lazyMessage       *lazyMessage
+	idSeed            uint64
+	matchCodeOnly     bool
+	includeAllContext bool
 }
 
-// Error implements the error interface
+// Error implements the error interface. A nil error renders as "<nil>".
+// With the default Terse verbosity it renders the type, code, message and
+// details; see SetVerbosity for the Verbose form.
 func (e *ManagedError) Error() string {
+	if e == nil {
+		return "<nil>"
+	}
+	if currentVerbosity() == Verbose {
+		return e.verboseError()
+	}
+	return e.terseError()
+}
+
+// terseError renders the Terse form of Error()
+func (e *ManagedError) terseError() string {
+	delimiter, code := currentCodeDelimiter(), e.EffectiveCode()
+	if e.Details != "" {
+		return fmt.Sprintf("[%s%s%s] %s: %s", e.Type, delimiter, code, e.GetMessage(), e.Details)
+	}
+	return fmt.Sprintf("[%s%s%s] %s", e.Type, delimiter, code, e.GetMessage())
+}
+
+// UserError returns a message suitable for end users, without the type and
+// code prefix. It falls back to Message when no user message is set.
+func (e *ManagedError) UserError() string {
+	if e == nil {
+		return ""
+	}
+	message := e.UserMessage
+	if message == "" {
+		message = e.GetMessage()
+	}
 	if e.Details != "" {
-		return fmt.Sprintf("[%s:%s] %s: %s", e.Type, e.Code, e.Message, e.Details)
+		return fmt.Sprintf("%s: %s", message, e.Details)
 	}
-	return fmt.Sprintf("[%s:%s] %s", e.Type, e.Code, e.Message)
+	return message
 }
 
 // Unwrap returns the underlying error
 func (e *ManagedError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
 	return e.Cause
 }
 
-// Is checks if the error matches the target error
+// GetCause returns the error's direct cause without unwrapping further. It
+// is the same as Unwrap, named for inspection code that reads more clearly
+// without the errors package. It returns nil for a nil error.
+func (e *ManagedError) GetCause() error {
+	if e == nil {
+		return nil
+	}
+	return e.Cause
+}
+
+// Temporary reports whether the error is temporary. It allows ManagedError
+// to satisfy interfaces such as net.Error's Temporary method.
+func (e *ManagedError) Temporary() bool {
+	return e != nil && e.Retryable
+}
+
+// Is checks if the error matches the target error. ManagedErrors match
+// when their types and codes are equal, or their codes alone for targets
+// marked with MatchByCodeOnly.
 func (e *ManagedError) Is(target error) bool {
-	if target == nil {
+	if e == nil || target == nil {
 		return false
 	}
 
-	var managedErr *ManagedError
-	if errors.As(target, &managedErr) {
-		return e.Type == managedErr.Type && e.Code == managedErr.Code
+	if managedErr, ok := asManaged(target); ok {
+		if managedErr.matchCodeOnly {
+			return codesEqual(e.Code, managedErr.Code)
+		}
+		return e.Type == managedErr.Type && codesEqual(e.Code, managedErr.Code)
 	}
 
 	return errors.Is(e.Cause, target)
 }
 
 // NewError creates a new ManagedError
-func NewError(errType ErrorType, code, message string) *ManagedError {
-	return &ManagedError{
-		Type:    errType,
-		Code:    code,
-		Message: message,
-		Context: make(map[string]string),
-	}
+func NewError(errType ErrorType, code Code, message string) *ManagedError {
+	return newError(1, errType, code, message, nil)
 }
 
 // NewErrorWithCause creates a new ManagedError wrapping an existing error
-func NewErrorWithCause(errType ErrorType, code, message string, cause error) *ManagedError {
-	return &ManagedError{
-		Type:    errType,
-		Code:    code,
-		Message: message,
-		Cause:   cause,
-		Context: make(map[string]string),
+func NewErrorWithCause(errType ErrorType, code Code, message string, cause error) *ManagedError {
+	return newError(1, errType, code, message, cause)
+}
+
+// NewErrorf creates a new ManagedError with a formatted message. When no
+// arguments are given the format is used as a literal message.
+func NewErrorf(errType ErrorType, code Code, format string, args ...interface{}) *ManagedError {
+	message := format
+	if len(args) > 0 {
+		message = fmt.Sprintf(format, args...)
 	}
+	return newError(1, errType, code, message, nil)
 }
 
-// WithDetails adds details to the error
+// NewErrorString creates a new ManagedError from an untyped string code.
+// It exists for callers that build codes dynamically; prefer NewError with
+// Code constants.
+func NewErrorString(errType ErrorType, code, message string) *ManagedError {
+	return newError(1, errType, Code(code), message, nil)
+}
+
+// NewErrorWithCauseString creates a new ManagedError wrapping an existing
+// error from an untyped string code.
+func NewErrorWithCauseString(errType ErrorType, code, message string, cause error) *ManagedError {
+	return newError(1, errType, Code(code), message, cause)
+}
+
+// newError builds a ManagedError. skip is the number of frames between
+// newError and the code that should appear at the top of the stack trace.
+func newError(skip int, errType ErrorType, code Code, message string, cause error) *ManagedError {
+	id, idSeed := newID()
+	e := &ManagedError{
+		ID:              id,
+		idSeed:          idSeed,
+		Type:            errType,
+		Code:            code,
+		Message:         message,
+		Cause:           cause,
+		HelpURL:         helpURL(code),
+		SuggestedAction: defaultAction(code),
+		Component:       currentDefaultComponent(),
+		Timestamp:       currentTime().UTC(),
+	}
+	if captureStack.Load() {
+		e.stack = callers(skip + 1)
+	}
+	if captureCaller.Load() {
+		e.Caller = caller(skip + 1)
+	}
+	if captureGoroutineID.Load() {
+		e.GoroutineID = goroutineID()
+	}
+	e.addGlobalContext()
+	e.validateCode()
+	return e
+}
+
+// WithDetails adds details to the error, replacing any lines added with
+// WithDetailLine
 func (e *ManagedError) WithDetails(details string) *ManagedError {
+	if e == nil {
+		return nil
+	}
 	e.Details = details
+	e.detailLines = nil
+	return e
+}
+
+// WithDetailLine appends a line to the error's details. Details holds the
+// lines joined with "; ", and JSON encodes them as an array when there is
+// more than one.
+func (e *ManagedError) WithDetailLine(line string) *ManagedError {
+	if e == nil {
+		return nil
+	}
+	if len(e.detailLines) == 0 && e.Details != "" {
+		e.detailLines = []string{e.Details}
+	}
+	e.detailLines = append(e.detailLines, line)
+	e.Details = strings.Join(e.detailLines, "; ")
+	return e
+}
+
+// DetailLines returns the lines added with WithDetailLine. Details set with
+// WithDetails or assigned directly are returned as a single line.
+func (e *ManagedError) DetailLines() []string {
+	if e == nil || e.Details == "" {
+		return nil
+	}
+	if strings.Join(e.detailLines, "; ") != e.Details {
+		return []string{e.Details}
+	}
+	return append([]string(nil), e.detailLines...)
+}
+
+// WithUserMessage sets the message returned by UserError
+func (e *ManagedError) WithUserMessage(message string) *ManagedError {
+	if e == nil {
+		return nil
+	}
+	e.UserMessage = message
 	return e
 }
 
 // WithContext adds context information to the error
 func (e *ManagedError) WithContext(key, value string) *ManagedError {
+	if e == nil {
+		return nil
+	}
 	if e.Context == nil {
 		e.Context = make(map[string]string)
 	}
+	if _, exists := e.Context[key]; !exists && contextFull(e.Context) {
+		e.Context[ContextTruncatedKey] = "true"
+		return e
+	}
 	e.Context[key] = value
 	return e
 }
 
 // WithRetryable sets whether the error is retryable
 func (e *ManagedError) WithRetryable(retryable bool) *ManagedError {
+	if e == nil {
+		return nil
+	}
 	e.Retryable = retryable
 	return e
 }
 
 // WithStatusCode sets the HTTP status code for the error
 func (e *ManagedError) WithStatusCode(code int) *ManagedError {
+	if e == nil {
+		return nil
+	}
 	e.StatusCode = code
 	return e
 }
 
+// WithRetryAfter sets how long callers should wait before retrying
+func (e *ManagedError) WithRetryAfter(d time.Duration) *ManagedError {
+	if e == nil {
+		return nil
+	}
+	e.RetryAfter = d
+	return e
+}
+
 // IsType checks if the error is of a specific type
 func IsType(err error, errType ErrorType) bool {
-	var managedErr *ManagedError
-	if errors.As(err, &managedErr) {
+	if managedErr, ok := asManaged(err); ok {
 		return managedErr.Type == errType
 	}
 	return false
 }
 
+// IsNotType checks if the error is a ManagedError of a type other than
+// errType. It returns false for nil and for errors that are not
+// ManagedErrors, so it is not the same as !IsType(err, errType).
+func IsNotType(err error, errType ErrorType) bool {
+	if managedErr, ok := asManaged(err); ok {
+		return managedErr.Type != errType
+	}
+	return false
+}
+
+// AnyType checks if the error is a ManagedError of any of the given types
+func AnyType(err error, types ...ErrorType) bool {
+	if managedErr, ok := asManaged(err); ok {
+		for _, errType := range types {
+			if managedErr.Type == errType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // IsRetryable checks if an error is retryable
 func IsRetryable(err error) bool {
-	var managedErr *ManagedError
-	if errors.As(err, &managedErr) {
+	if managedErr, ok := asManaged(err); ok {
 		return managedErr.Retryable
 	}
 	return false
@@ -127,13 +359,22 @@ func IsRetryable(err error) bool {
 
 // GetContext retrieves context from an error
 func GetContext(err error) map[string]string {
-	var managedErr *ManagedError
-	if errors.As(err, &managedErr) {
+	if managedErr, ok := asManaged(err); ok {
 		return managedErr.Context
 	}
 	return nil
 }
 
+// asManaged finds the first ManagedError in err's chain. Typed nil
+// pointers are treated as absent.
+func asManaged(err error) (*ManagedError, bool) {
+	var managedErr *ManagedError
+	if errors.As(err, &managedErr) && managedErr != nil {
+		return managedErr, true
+	}
+	return nil, false
+}
+
 // Wrap wraps an existing error with additional context
 func Wrap(err error, message string) error {
 	return fmt.Errorf("%s: %w", message, err)
@@ -143,3 +384,23 @@ func Wrap(err error, message string) error {
 func Wrapf(err error, format string, args ...interface{}) error {
 	return fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err)
 }
+
+// WrapPreserving wraps err in a new ManagedError with the given code and
+// message. Unlike Wrap, the wrapper inherits the Type, StatusCode,
+// Retryable and RetryAfter of the first ManagedError in err's chain, so a
+// retryable external error stays retryable and keeps its HTTP status
+// through the layer boundary; use the With methods to override them.
+// Other errors are wrapped as a SystemError. It returns nil for nil.
+func WrapPreserving(err error, code Code, message string) *ManagedError {
+	if err == nil {
+		return nil
+	}
+	wrapper := newError(1, SystemError, code, message, err)
+	if inner, ok := asManaged(err); ok {
+		wrapper.Type = inner.Type
+		wrapper.StatusCode = inner.StatusCode
+		wrapper.Retryable = inner.Retryable
+		wrapper.RetryAfter = inner.RetryAfter
+	}
+	return wrapper
+}
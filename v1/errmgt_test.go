@@ -2,7 +2,9 @@ package errmgt
 
 import (
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 )
 
 func TestNewError(t *testing.T) {
@@ -20,8 +22,28 @@ func TestNewError(t *testing.T) {
 		t.Errorf("Expected message 'Input validation failed', got '%s'", err.Message)
 	}
 
-	if err.Context == nil {
-		t.Error("Expected context to be initialized")
+	if err.Context != nil {
+		t.Error("Expected context to be initialized lazily")
+	}
+}
+
+func TestNewErrorf(t *testing.T) {
+	err := NewErrorf(BusinessError, "insufficient_funds", "balance %d is below %d", 10, 50)
+
+	if err.Code != "insufficient_funds" {
+		t.Errorf("Expected code 'insufficient_funds', got '%s'", err.Code)
+	}
+
+	if err.Message != "balance 10 is below 50" {
+		t.Errorf("Expected message 'balance 10 is below 50', got '%s'", err.Message)
+	}
+
+	// Without arguments the format is taken literally. NewErrorf is called
+	// through a variable so vet does not flag the deliberately bare %.
+	newErrorf := NewErrorf
+	literal := newErrorf(ValidationError, "invalid_discount", "discount must be below 100%")
+	if literal.Message != "discount must be below 100%" {
+		t.Errorf("Expected literal message, got '%s'", literal.Message)
 	}
 }
 
@@ -159,6 +181,10 @@ func TestGetContext(t *testing.T) {
 		t.Error("Expected operation context to be 'select'")
 	}
 
+	if context := GetContext(NewError(SystemError, "db_error", "Database error")); context != nil {
+		t.Error("Expected no context for an error without context")
+	}
+
 	regularErr := errors.New("regular error")
 	context = GetContext(regularErr)
 	if context != nil {
@@ -223,3 +249,357 @@ func TestManagedErrorIs(t *testing.T) {
 		t.Error("Expected error to be identified as its cause")
 	}
 }
+
+func TestManagedErrorUserError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      *ManagedError
+		expected string
+	}{
+		{
+			name:     "falls back to message",
+			err:      NewError(ValidationError, "invalid_email", "Invalid email format"),
+			expected: "Invalid email format",
+		},
+		{
+			name: "with user message",
+			err: NewError(ValidationError, "invalid_email", "Invalid email format").
+				WithUserMessage("Please enter a valid email address"),
+			expected: "Please enter a valid email address",
+		},
+		{
+			name: "with details",
+			err: NewError(ValidationError, "invalid_email", "Invalid email format").
+				WithUserMessage("Please enter a valid email address").
+				WithDetails("Email must contain @ symbol"),
+			expected: "Please enter a valid email address: Email must contain @ symbol",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.UserError(); got != tt.expected {
+				t.Errorf("UserError() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+
+	err := NewError(ValidationError, "invalid_email", "Invalid email format").
+		WithUserMessage("Please enter a valid email address")
+	if err.Error() != "[validation:invalid_email] Invalid email format" {
+		t.Errorf("Expected Error() to keep the verbose form, got '%s'", err.Error())
+	}
+}
+
+func TestNewErrorString(t *testing.T) {
+	code := "invalid_" + "input"
+	err := NewErrorString(ValidationError, code, "Input validation failed")
+
+	if err.Code != "invalid_input" {
+		t.Errorf("Expected code 'invalid_input', got '%s'", err.Code)
+	}
+
+	cause := errors.New("original error")
+	err = NewErrorWithCauseString(SystemError, code, "Input validation failed", cause)
+	if err.Code.String() != "invalid_input" {
+		t.Errorf("Expected code 'invalid_input', got '%s'", err.Code)
+	}
+	if err.Cause != cause {
+		t.Error("Expected cause to be set")
+	}
+}
+
+func BenchmarkNewError(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = NewError(ValidationError, "invalid_input", "Input validation failed")
+	}
+}
+
+func BenchmarkNewErrorWithContext(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = NewError(ValidationError, "invalid_input", "Input validation failed").
+			WithContext("field", "email")
+	}
+}
+
+// TestNewErrorAllocs guards the single allocation BenchmarkNewError
+// reports, with and without the default ID
+func TestNewErrorAllocs(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = NewError(ValidationError, "invalid_input", "Input validation failed")
+	})
+	if allocs > 1 {
+		t.Errorf("Expected NewError to allocate once, got %v", allocs)
+	}
+
+	SetIDGenerator(nil)
+	defer ResetIDGenerator()
+	allocs = testing.AllocsPerRun(100, func() {
+		_ = NewError(ValidationError, "invalid_input", "Input validation failed")
+	})
+	if allocs > 1 {
+		t.Errorf("Expected NewError without IDs to allocate once, got %v", allocs)
+	}
+}
+
+func TestManagedErrorGetCause(t *testing.T) {
+	root := errors.New("root")
+	middle := NewErrorWithCause(SystemError, "db_error", "Database error", root)
+	outer := NewErrorWithCause(BusinessError, "order_failed", "Order failed", middle)
+
+	if cause := outer.GetCause(); cause != middle {
+		t.Errorf("GetCause() = %v, want the direct cause %v", cause, middle)
+	}
+
+	if cause := NewError(ValidationError, "invalid_input", "bad").GetCause(); cause != nil {
+		t.Errorf("GetCause() = %v, want nil for an error without a cause", cause)
+	}
+}
+
+func TestManagedErrorNilReceiver(t *testing.T) {
+	var managedErr *ManagedError
+	var err error = managedErr
+
+	if got := err.Error(); got != "<nil>" {
+		t.Errorf("Error() = %q, want %q", got, "<nil>")
+	}
+	if got := managedErr.UserError(); got != "" {
+		t.Errorf("UserError() = %q, want empty", got)
+	}
+	if managedErr.Unwrap() != nil {
+		t.Error("Unwrap() should return nil for a nil error")
+	}
+	if managedErr.GetCause() != nil {
+		t.Error("GetCause() should return nil for a nil error")
+	}
+	if managedErr.Temporary() {
+		t.Error("Temporary() should return false for a nil error")
+	}
+	if managedErr.Is(NewError(ValidationError, "invalid_input", "bad")) {
+		t.Error("Is() should return false for a nil error")
+	}
+	if managedErr.StackTrace() != nil {
+		t.Error("StackTrace() should return nil for a nil error")
+	}
+	if _, _, ok := managedErr.SpanContext(); ok {
+		t.Error("SpanContext() should report false for a nil error")
+	}
+	if managedErr.Sanitized() != nil {
+		t.Error("Sanitized() should return nil for a nil error")
+	}
+	if got := fmt.Sprintf("%+v", managedErr); got != "<nil>" {
+		t.Errorf("%%+v = %q, want %q", got, "<nil>")
+	}
+	if got := managedErr.LogValue().String(); got != "<nil>" {
+		t.Errorf("LogValue() = %q, want %q", got, "<nil>")
+	}
+
+	built := managedErr.
+		WithDetails("details").
+		WithDetailLine("line").
+		WithUserMessage("message").
+		WithContext("key", "value").
+		WithContextFrom(NewError(SystemError, "db_error", "").WithContext("table", "users")).
+		WithRetryable(true).
+		WithStatusCode(500).
+		WithRetryAfter(time.Second).
+		WithCode("other").
+		WithTags("user_facing").
+		WithSeverity(SeverityCritical).
+		WithSpanContext("trace", "span").
+		WithMetadata("metadata").
+		WithFingerprint("part").
+		WithSuggestedAction("retry").
+		WithHelpURL("https://example.com").
+		WithResource("user", "42").
+		WithComponent("accounts").
+		WithScope("lookup").
+		PrependScope("accounts").
+		WithTransient(true).
+		WithExpiry(time.Minute).
+		WithPriority(1).
+		WithAttempt(1).
+		WithDependency("users-api").
+		WithOccurrences(2).
+		IncrementOccurrences().
+		WithPublicContextKeys("key").
+		WithDefaultStatus().
+		MatchByCodeOnly().
+		IncludeAllContext().
+		MarkBoundary().
+		MarkPII().
+		Observe()
+	if built != nil {
+		t.Errorf("Expected builder methods to return nil for a nil error, got %v", built)
+	}
+	if got := managedErr.LocalizedUserError("de"); got != "" {
+		t.Errorf("LocalizedUserError() = %q, want empty", got)
+	}
+	if data, err := managedErr.MarshalJSON(); err != nil || string(data) != "null" {
+		t.Errorf("MarshalJSON() = %s, %v, want null", data, err)
+	}
+
+	if IsType(err, ValidationError) {
+		t.Error("IsType() should return false for a typed nil")
+	}
+	if IsRetryable(err) {
+		t.Error("IsRetryable() should return false for a typed nil")
+	}
+	if GetContext(err) != nil {
+		t.Error("GetContext() should return nil for a typed nil")
+	}
+	if HasTag(err, "user_facing") {
+		t.Error("HasTag() should return false for a typed nil")
+	}
+	if GetSeverity(err) != SeverityCritical {
+		t.Errorf("GetSeverity() = %v, want %v", GetSeverity(err), SeverityCritical)
+	}
+}
+
+type queryError struct {
+	Query string
+}
+
+func (e *queryError) Error() string {
+	return "query failed: " + e.Query
+}
+
+func TestErrorsAsConcreteCause(t *testing.T) {
+	cause := &queryError{Query: "SELECT 1"}
+	err := NewErrorWithCause(BusinessError, "signup_failed", "Signup failed",
+		fmt.Errorf("create user: %w",
+			NewErrorWithCause(SystemError, "db_error", "Database error",
+				NewErrorWithCause(ExternalError, "driver_error", "Driver error", cause))))
+
+	var target *queryError
+	if !errors.As(err, &target) {
+		t.Fatal("Expected errors.As to find the cause three levels deep")
+	}
+
+	if target != cause {
+		t.Errorf("Expected errors.As to extract the original cause, got %v", target)
+	}
+
+	var outer *ManagedError
+	if !errors.As(err, &outer) || outer != err {
+		t.Error("Expected errors.As to stop at the outermost ManagedError")
+	}
+}
+
+func TestErrorsAsConcreteCauseInMultiError(t *testing.T) {
+	cause := &queryError{Query: "SELECT 2"}
+	err := NewErrorWithCause(SystemError, "batch_failed", "Batch failed",
+		Append(errors.New("first"), NewErrorWithCause(SystemError, "db_error", "Database error", cause)))
+
+	var target *queryError
+	if !errors.As(err, &target) || target != cause {
+		t.Errorf("Expected errors.As to find the cause inside a MultiError, got %v", target)
+	}
+}
+
+func TestManagedErrorWithDetailLine(t *testing.T) {
+	err := NewError(ValidationError, "invalid_order", "Invalid order").
+		WithDetailLine("quantity must be positive").
+		WithDetailLine("currency is not supported")
+
+	if err.Details != "quantity must be positive; currency is not supported" {
+		t.Errorf("Expected joined details, got '%s'", err.Details)
+	}
+
+	if got := err.Error(); got != "[validation:invalid_order] Invalid order: quantity must be positive; currency is not supported" {
+		t.Errorf("Unexpected Error() output: %s", got)
+	}
+
+	lines := err.DetailLines()
+	if len(lines) != 2 || lines[0] != "quantity must be positive" || lines[1] != "currency is not supported" {
+		t.Errorf("Expected two detail lines, got %v", lines)
+	}
+
+	if err.WithDetails("replaced").DetailLines()[0] != "replaced" || len(err.DetailLines()) != 1 {
+		t.Errorf("Expected WithDetails to replace the lines, got %v", err.DetailLines())
+	}
+
+	if lines := err.WithDetailLine("added").DetailLines(); len(lines) != 2 || lines[0] != "replaced" {
+		t.Errorf("Expected existing details to become the first line, got %v", lines)
+	}
+
+	err.Details = "assigned"
+	if lines := err.DetailLines(); len(lines) != 1 || lines[0] != "assigned" {
+		t.Errorf("Expected directly assigned details to be a single line, got %v", lines)
+	}
+}
+
+func TestIsNotType(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", NewError(SystemError, "db_error", "Database error"))
+
+	if !IsNotType(err, ValidationError) {
+		t.Error("Expected IsNotType to be true for a different type")
+	}
+
+	if IsNotType(err, SystemError) {
+		t.Error("Expected IsNotType to be false for the same type")
+	}
+
+	if IsNotType(errors.New("plain"), ValidationError) {
+		t.Error("Expected IsNotType to be false for a non-managed error")
+	}
+
+	if IsNotType(nil, ValidationError) {
+		t.Error("Expected IsNotType to be false for nil")
+	}
+}
+
+func TestAnyType(t *testing.T) {
+	err := NewError(ExternalError, "api_timeout", "API timeout")
+
+	if !AnyType(err, SystemError, ExternalError) {
+		t.Error("Expected AnyType to match one of the types")
+	}
+
+	if AnyType(err, ValidationError, BusinessError) {
+		t.Error("Expected AnyType not to match other types")
+	}
+
+	if AnyType(err) {
+		t.Error("Expected AnyType without types to be false")
+	}
+
+	if AnyType(errors.New("plain"), SystemError) {
+		t.Error("Expected AnyType to be false for a non-managed error")
+	}
+}
+
+func TestWrapPreserving(t *testing.T) {
+	inner := Timeout("fetch user", time.Second)
+	err := WrapPreserving(fmt.Errorf("client: %w", inner), "user_lookup_failed", "User lookup failed")
+
+	if err.Type != ExternalError || err.Code != "user_lookup_failed" || err.Message != "User lookup failed" {
+		t.Errorf("Unexpected wrapper: %v", err)
+	}
+
+	if !err.Retryable || err.StatusCode != 504 || err.RetryAfter != DefaultTimeoutRetryAfter {
+		t.Errorf("Expected retryable, status and retry-after to be inherited, got %+v", err)
+	}
+
+	if !errors.Is(err, inner) {
+		t.Error("Expected the wrapped error to remain the cause")
+	}
+
+	if overridden := WrapPreserving(inner, "lookup_failed", "Lookup failed").WithRetryable(false); overridden.Retryable || inner.Retryable != true {
+		t.Error("Expected inherited fields to be overridable without touching the inner error")
+	}
+}
+
+func TestWrapPreservingPlainError(t *testing.T) {
+	err := WrapPreserving(errors.New("disk full"), "save_failed", "Save failed")
+
+	if err.Type != SystemError || err.Retryable || err.StatusCode != 0 {
+		t.Errorf("Expected a plain SystemError wrapper, got %+v", err)
+	}
+
+	if WrapPreserving(nil, "save_failed", "Save failed") != nil {
+		t.Error("Expected nil for nil")
+	}
+}
@@ -0,0 +1,64 @@
+// Package errtest provides test assertions for errors produced with errmgt.
+package errtest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kerzzt/go-errmgt"
+)
+
+// managed returns the ManagedError in err's chain, failing the test if
+// there is none
+func managed(t testing.TB, err error) (*errmgt.ManagedError, bool) {
+	t.Helper()
+	var managedErr *errmgt.ManagedError
+	if !errors.As(err, &managedErr) || managedErr == nil {
+		t.Errorf("expected a ManagedError, got %T: %v", err, err)
+		return nil, false
+	}
+	return managedErr, true
+}
+
+// AssertType checks that err is a ManagedError of type want
+func AssertType(t testing.TB, err error, want errmgt.ErrorType) {
+	t.Helper()
+	if managedErr, ok := managed(t, err); ok && managedErr.Type != want {
+		t.Errorf("expected error type %q, got %q (%v)", want, managedErr.Type, err)
+	}
+}
+
+// AssertCode checks that err is a ManagedError with code want
+func AssertCode(t testing.TB, err error, want errmgt.Code) {
+	t.Helper()
+	if managedErr, ok := managed(t, err); ok && managedErr.Code != want {
+		t.Errorf("expected error code %q, got %q (%v)", want, managedErr.Code, err)
+	}
+}
+
+// AssertRetryable checks that err is a ManagedError whose Retryable flag
+// equals want
+func AssertRetryable(t testing.TB, err error, want bool) {
+	t.Helper()
+	if managedErr, ok := managed(t, err); ok && managedErr.Retryable != want {
+		t.Errorf("expected retryable to be %t, got %t (%v)", want, managedErr.Retryable, err)
+	}
+}
+
+// AssertContext checks that err is a ManagedError whose context holds want
+// under key
+func AssertContext(t testing.TB, err error, key, want string) {
+	t.Helper()
+	managedErr, ok := managed(t, err)
+	if !ok {
+		return
+	}
+	got, exists := managedErr.Context[key]
+	if !exists {
+		t.Errorf("expected context key %q to be set (%v)", key, err)
+		return
+	}
+	if got != want {
+		t.Errorf("expected context %q to be %q, got %q (%v)", key, want, got, err)
+	}
+}
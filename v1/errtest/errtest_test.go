@@ -0,0 +1,90 @@
+package errtest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kerzzt/go-errmgt"
+)
+
+// recorder captures assertion failures instead of failing the test
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func expectFailure(t *testing.T, r *recorder, contains string) {
+	t.Helper()
+	if len(r.failures) != 1 {
+		t.Fatalf("Expected 1 failure, got %v", r.failures)
+	}
+	if !strings.Contains(r.failures[0], contains) {
+		t.Errorf("Expected failure to contain %q, got %q", contains, r.failures[0])
+	}
+}
+
+func testError() error {
+	return fmt.Errorf("loading user: %w", errmgt.NewError(errmgt.ValidationError, "invalid_email", "Invalid email").
+		WithRetryable(true).
+		WithContext("field", "email"))
+}
+
+func TestAssertionsPass(t *testing.T) {
+	r := &recorder{TB: t}
+	err := testError()
+
+	AssertType(r, err, errmgt.ValidationError)
+	AssertCode(r, err, "invalid_email")
+	AssertRetryable(r, err, true)
+	AssertContext(r, err, "field", "email")
+
+	if len(r.failures) != 0 {
+		t.Errorf("Expected no failures, got %v", r.failures)
+	}
+}
+
+func TestAssertType(t *testing.T) {
+	r := &recorder{TB: t}
+	AssertType(r, testError(), errmgt.SystemError)
+	expectFailure(t, r, `expected error type "system", got "validation"`)
+}
+
+func TestAssertCode(t *testing.T) {
+	r := &recorder{TB: t}
+	AssertCode(r, testError(), "invalid_phone")
+	expectFailure(t, r, `expected error code "invalid_phone", got "invalid_email"`)
+}
+
+func TestAssertRetryable(t *testing.T) {
+	r := &recorder{TB: t}
+	AssertRetryable(r, testError(), false)
+	expectFailure(t, r, "expected retryable to be false, got true")
+}
+
+func TestAssertContext(t *testing.T) {
+	r := &recorder{TB: t}
+	AssertContext(r, testError(), "field", "phone")
+	expectFailure(t, r, `expected context "field" to be "phone", got "email"`)
+
+	r = &recorder{TB: t}
+	AssertContext(r, testError(), "user_id", "123")
+	expectFailure(t, r, `expected context key "user_id" to be set`)
+}
+
+func TestAssertNonManaged(t *testing.T) {
+	r := &recorder{TB: t}
+	AssertType(r, errors.New("plain error"), errmgt.ValidationError)
+	expectFailure(t, r, "expected a ManagedError, got *errors.errorString")
+
+	r = &recorder{TB: t}
+	AssertCode(r, nil, "invalid_email")
+	expectFailure(t, r, "expected a ManagedError, got <nil>")
+}
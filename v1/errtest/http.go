@@ -0,0 +1,28 @@
+package errtest
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	errhttp "github.com/kerzzt/go-errmgt/http"
+)
+
+// RoundTrip serves err with http.WriteHTTP from a test server, fetches it
+// with an HTTP client and returns what http.DecodeHTTP reconstructs, so
+// tests can check that an error survives the trip between services.
+// WriteHTTP sends the sanitized error, so only the fields Sanitized keeps
+// come back. The second result reports failures of the request itself.
+func RoundTrip(err error) (error, error) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errhttp.WriteHTTP(w, err)
+	}))
+	defer server.Close()
+
+	resp, getErr := server.Client().Get(server.URL)
+	if getErr != nil {
+		return nil, getErr
+	}
+	defer resp.Body.Close()
+
+	return errhttp.DecodeHTTP(resp), nil
+}
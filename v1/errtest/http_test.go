@@ -0,0 +1,118 @@
+package errtest
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kerzzt/go-errmgt"
+)
+
+// populatedError returns an error with every exported field set
+func populatedError() *errmgt.ManagedError {
+	err := errmgt.NewErrorWithCause(errmgt.ExternalError, "api_timeout", "API timeout",
+		errmgt.NewErrorWithCause(errmgt.SystemError, "dial_failed", "Dial failed", errors.New("connection refused"))).
+		WithDetailLine("upstream did not respond").
+		WithDetailLine("gave up after 3 attempts").
+		WithUserMessage("The service is temporarily unavailable").
+		WithContext("endpoint", "/users").
+		WithPublicContextKeys("endpoint").
+		WithRetryable(true).
+		WithRetryAfter(time.Second).
+		WithStatusCode(504).
+		WithSeverity(errmgt.SeverityCritical).
+		WithTags("user_facing").
+		WithSpanContext("4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7").
+		WithOccurrences(47).
+		WithHelpURL("https://docs.example.com/errors/api_timeout").
+		WithMetadata(map[string]interface{}{"region": "eu-1"}).
+		WithFingerprint("upstream", "users").
+		WithSuggestedAction("Retry after checking the upstream status page").
+		MarkBoundary().
+		WithDependency("users-api").
+		WithPriority(10).
+		WithResource("user", "42").
+		WithAttempt(3).
+		WithComponent("accounts").
+		WithScope("accounts.lookup").
+		MarkPII().
+		WithTransient(true).
+		Observe()
+	err.ID = "4f1c2a9e8b7d6c5a"
+	err.Caller = "service/user.go:42"
+	err.GoroutineID = 7
+	err.ExpiresAt = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	return err
+}
+
+func TestRoundTrip(t *testing.T) {
+	original := populatedError()
+
+	decoded, err := RoundTrip(original)
+	if err != nil {
+		t.Fatalf("RoundTrip() error: %v", err)
+	}
+
+	var managedErr *errmgt.ManagedError
+	if !errors.As(decoded, &managedErr) {
+		t.Fatalf("Expected a ManagedError, got %T: %v", decoded, decoded)
+	}
+
+	// The response carries the sanitized error, so every field must match
+	// the sanitized copy: public fields survive, internal ones are dropped
+	populated := reflect.ValueOf(original).Elem()
+	want := reflect.ValueOf(original.Sanitized()).Elem()
+	got := reflect.ValueOf(managedErr).Elem()
+	for i := 0; i < want.NumField(); i++ {
+		field := want.Type().Field(i)
+		if !field.IsExported() || field.Name == "Cause" {
+			continue
+		}
+		if populated.Field(i).IsZero() {
+			t.Errorf("populatedError does not set %s; set it so the round trip covers it", field.Name)
+			continue
+		}
+		if !reflect.DeepEqual(got.Field(i).Interface(), want.Field(i).Interface()) {
+			t.Errorf("%s = %#v after the round trip, want %#v", field.Name, got.Field(i).Interface(), want.Field(i).Interface())
+		}
+	}
+
+	if len(managedErr.DetailLines()) != 0 {
+		t.Errorf("DetailLines() = %q after the round trip, want none", managedErr.DetailLines())
+	}
+
+	if managedErr.Cause != nil {
+		t.Errorf("Cause = %v after the round trip, want none below a boundary", managedErr.Cause)
+	}
+}
+
+func TestRoundTripBoundaryChain(t *testing.T) {
+	original := errmgt.Wrap(
+		errmgt.NewErrorWithCause(errmgt.SystemError, "dial_failed", "Dial failed", errors.New("connection refused")).MarkBoundary(),
+		"fetch users")
+
+	decoded, err := RoundTrip(original)
+	if err != nil {
+		t.Fatalf("RoundTrip() error: %v", err)
+	}
+
+	if !errors.Is(decoded, errmgt.NewError(errmgt.SystemError, "dial_failed", "")) {
+		t.Error("Expected ManagedErrors down to the boundary to survive the round trip")
+	}
+
+	if strings.Contains(decoded.Error(), "connection refused") {
+		t.Errorf("Expected the cause below the boundary to be dropped, got %v", decoded)
+	}
+}
+
+func TestRoundTripPlainError(t *testing.T) {
+	decoded, err := RoundTrip(errors.New("secret"))
+	if err != nil {
+		t.Fatalf("RoundTrip() error: %v", err)
+	}
+
+	AssertType(t, decoded, errmgt.SystemError)
+	AssertCode(t, decoded, "internal_error")
+}
@@ -0,0 +1,25 @@
+package errmgt_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kerzzt/go-errmgt"
+)
+
+// Codes are typically declared together in their own package, e.g. codes,
+// so that call sites read errmgt.NewError(errmgt.ValidationError, codes.InvalidEmail, ...).
+const (
+	InvalidEmail errmgt.Code = "invalid_email"
+	UserNotFound errmgt.Code = "user_not_found"
+)
+
+func ExampleCode() {
+	err := errmgt.NewError(errmgt.ValidationError, InvalidEmail, "Email format is invalid")
+
+	fmt.Println(err.Error())
+	fmt.Println(errors.Is(err, errmgt.NewError(errmgt.ValidationError, InvalidEmail, "")))
+	// Output:
+	// [validation:invalid_email] Email format is invalid
+	// true
+}
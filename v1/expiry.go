@@ -0,0 +1,25 @@
+package errmgt
+
+import "time"
+
+// WithExpiry marks the error as valid for d from now, for callers that
+// cache errors such as "service unavailable" to avoid hammering a
+// dependency. Once expired, the cached error should be evicted and the
+// operation retried.
+func (e *ManagedError) WithExpiry(d time.Duration) *ManagedError {
+	if e == nil {
+		return nil
+	}
+	e.ExpiresAt = currentTime().Add(d)
+	return e
+}
+
+// IsExpired reports whether the first ManagedError in err's chain has an
+// expiry that has passed. Errors without an expiry never expire.
+func IsExpired(err error) bool {
+	managedErr, ok := asManaged(err)
+	if !ok || managedErr.ExpiresAt.IsZero() {
+		return false
+	}
+	return !currentTime().Before(managedErr.ExpiresAt)
+}
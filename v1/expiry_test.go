@@ -0,0 +1,62 @@
+package errmgt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithExpiry(t *testing.T) {
+	before := time.Now()
+	err := NewError(ExternalError, "service_unavailable", "Service unavailable").WithExpiry(time.Minute)
+
+	if err.ExpiresAt.Before(before.Add(time.Minute)) || err.ExpiresAt.After(time.Now().Add(time.Minute)) {
+		t.Errorf("Expected expiry about a minute from now, got %v", err.ExpiresAt)
+	}
+
+	if IsExpired(fmt.Errorf("cached: %w", err)) {
+		t.Error("Expected an error expiring in the future not to be expired")
+	}
+}
+
+func TestIsExpired(t *testing.T) {
+	expired := NewError(ExternalError, "service_unavailable", "Service unavailable").WithExpiry(-time.Second)
+	if !IsExpired(fmt.Errorf("cached: %w", expired)) {
+		t.Error("Expected an error whose expiry has passed to be expired")
+	}
+
+	if IsExpired(NewError(ExternalError, "service_unavailable", "Service unavailable")) {
+		t.Error("Expected an error without an expiry not to be expired")
+	}
+
+	if IsExpired(errors.New("plain")) || IsExpired(nil) {
+		t.Error("Expected plain and nil errors not to be expired")
+	}
+}
+
+func TestExpiryJSON(t *testing.T) {
+	expiresAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	err := NewError(ExternalError, "service_unavailable", "Service unavailable")
+	err.ExpiresAt = expiresAt
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("Failed to marshal error: %v", marshalErr)
+	}
+
+	if !strings.Contains(string(data), `"expires_at":"2024-03-01T12:00:00Z"`) {
+		t.Errorf("Expected expires_at in JSON, got %s", data)
+	}
+
+	var decoded ManagedError
+	if unmarshalErr := json.Unmarshal(data, &decoded); unmarshalErr != nil {
+		t.Fatalf("Failed to unmarshal error: %v", unmarshalErr)
+	}
+
+	if !decoded.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("Expected expiry %v after round trip, got %v", expiresAt, decoded.ExpiresAt)
+	}
+}
@@ -0,0 +1,34 @@
+package errmgt
+
+// WithFingerprint sets the fingerprint monitoring tools use to group the
+// error, replacing the default of type and code. It can merge several
+// codes into one group or split one code, for example by a context value.
+func (e *ManagedError) WithFingerprint(parts ...string) *ManagedError {
+	if e == nil {
+		return nil
+	}
+	e.Fingerprint = parts
+	return e
+}
+
+// GetFingerprint returns the fingerprint for grouping err. For a
+// ManagedError it is the fingerprint set with WithFingerprint, or its type
+// and code when none was set. Other errors, and ManagedErrors without a
+// code, are fingerprinted by their message as normalized by Normalize. It
+// returns nil for nil.
+func GetFingerprint(err error) []string {
+	if err == nil {
+		return nil
+	}
+	if managedErr, ok := asManaged(err); ok {
+		if len(managedErr.Fingerprint) > 0 {
+			return append([]string(nil), managedErr.Fingerprint...)
+		}
+		code := managedErr.groupingCode()
+		if code == "" {
+			return []string{string(managedErr.Type), Normalize(err)}
+		}
+		return []string{string(managedErr.Type), string(code)}
+	}
+	return []string{Normalize(err)}
+}
@@ -0,0 +1,53 @@
+package errmgt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetFingerprint(t *testing.T) {
+	err := NewError(ExternalError, "api_timeout", "API timeout")
+
+	if got := GetFingerprint(fmt.Errorf("wrapped: %w", err)); !reflect.DeepEqual(got, []string{"external", "api_timeout"}) {
+		t.Errorf("Expected default fingerprint of type and code, got %v", got)
+	}
+
+	err.WithFingerprint("upstream", "payments")
+	got := GetFingerprint(err)
+	if !reflect.DeepEqual(got, []string{"upstream", "payments"}) {
+		t.Errorf("Expected explicit fingerprint, got %v", got)
+	}
+
+	got[0] = "changed"
+	if err.Fingerprint[0] != "upstream" {
+		t.Error("Expected GetFingerprint to return a copy")
+	}
+
+	if got := GetFingerprint(NewError(SystemError, "", "disk 2 full")); !reflect.DeepEqual(got, []string{"system", "disk * full"}) {
+		t.Errorf("Expected uncoded errors to be fingerprinted by type and message, got %v", got)
+	}
+
+	if got := GetFingerprint(errors.New("disk full")); !reflect.DeepEqual(got, []string{"disk full"}) {
+		t.Errorf("Expected plain errors to be fingerprinted by message, got %v", got)
+	}
+
+	if got := GetFingerprint(nil); got != nil {
+		t.Errorf("Expected nil for nil, got %v", got)
+	}
+}
+
+func TestManagedErrorFingerprintJSON(t *testing.T) {
+	err := NewError(ExternalError, "api_timeout", "API timeout").WithFingerprint("upstream")
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("Failed to marshal: %v", marshalErr)
+	}
+	if !strings.Contains(string(data), `"fingerprint":["upstream"]`) {
+		t.Errorf("Expected fingerprint in JSON, got %s", data)
+	}
+}
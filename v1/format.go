@@ -0,0 +1,131 @@
+package errmgt
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// Format implements fmt.Formatter. The %s and %v verbs print Error(), %q
+// prints it quoted, and %+v prints a multi-line description including the
+// error's ID, caller, goroutine, suggested action, context, cause chain
+// and stack trace.
+func (e *ManagedError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			_, _ = io.WriteString(s, e.verbose())
+			return
+		}
+		_, _ = io.WriteString(s, e.Error())
+	case 's':
+		_, _ = io.WriteString(s, e.Error())
+	case 'q':
+		_, _ = fmt.Fprintf(s, "%q", e.Error())
+	}
+}
+
+var maxChainDisplay atomic.Int64
+
+// SetMaxChainDisplay limits how many causes %+v, and Error() at Verbose,
+// render below the error. When the chain is longer, the rest is
+// summarized as "... (N more)". A value of zero or less, the default,
+// renders the whole chain.
+func SetMaxChainDisplay(n int) {
+	maxChainDisplay.Store(int64(n))
+}
+
+// DefaultCodeDelimiter separates the type and code in Error() unless
+// changed with SetCodeDelimiter
+const DefaultCodeDelimiter = ":"
+
+var codeDelimiter atomic.Value
+
+// SetCodeDelimiter changes the separator between the type and code in
+// Error(), so "[validation:invalid_email]" can become
+// "[validation/invalid_email]" to suit existing log parsers. It returns an
+// error and keeps the current delimiter when delimiter is empty or
+// contains a square bracket, since either would make the prefix ambiguous.
+func SetCodeDelimiter(delimiter string) error {
+	if delimiter == "" || strings.ContainsAny(delimiter, "[]") {
+		return fmt.Errorf("errmgt: invalid code delimiter %q", delimiter)
+	}
+	codeDelimiter.Store(delimiter)
+	return nil
+}
+
+// currentCodeDelimiter returns the delimiter set with SetCodeDelimiter
+func currentCodeDelimiter() string {
+	if delimiter, ok := codeDelimiter.Load().(string); ok {
+		return delimiter
+	}
+	return DefaultCodeDelimiter
+}
+
+// verbose renders the %+v form of the error
+func (e *ManagedError) verbose() string {
+	if e == nil {
+		return e.Error()
+	}
+	var b strings.Builder
+	e.writeVerbose(&b)
+
+	limit := int(maxChainDisplay.Load())
+	cause := e.Cause
+	for level := 0; cause != nil; level++ {
+		if limit > 0 && level >= limit {
+			fmt.Fprintf(&b, "\n... (%d more)", Depth(cause)+1)
+			break
+		}
+		b.WriteString("\ncaused by: ")
+		next, ok := cause.(*ManagedError)
+		if !ok || next == nil {
+			fmt.Fprintf(&b, "%+v", cause)
+			break
+		}
+		next.writeVerbose(&b)
+		cause = next.Cause
+	}
+	return b.String()
+}
+
+// writeVerbose writes the %+v form of the error alone, without its causes
+func (e *ManagedError) writeVerbose(b *strings.Builder) {
+	b.WriteString(e.terseError())
+
+	if id := e.GetID(); id != "" {
+		fmt.Fprintf(b, "\n    id: %s", id)
+	}
+	if e.Caller != "" {
+		fmt.Fprintf(b, "\n    caller: %s", e.Caller)
+	}
+	if e.GoroutineID != 0 {
+		fmt.Fprintf(b, "\n    goroutine: %d", e.GoroutineID)
+	}
+	if e.SuggestedAction != "" {
+		fmt.Fprintf(b, "\n    action: %s", e.SuggestedAction)
+	}
+	if e.Occurrences > 0 {
+		fmt.Fprintf(b, "\n    occurrences: %d", e.Occurrences)
+	}
+	if len(e.Context) > 0 {
+		keys := make([]string, 0, len(e.Context))
+		for k := range e.Context {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		b.WriteString("\n    context:")
+		for _, k := range keys {
+			fmt.Fprintf(b, "\n        %s=%s", k, e.Context[k])
+		}
+	}
+	if trace := e.StackTrace(); len(trace) > 0 {
+		b.WriteString("\n    stack:")
+		for _, frame := range trace {
+			fmt.Fprintf(b, "\n        %s\n            %s:%d", frame.Function, frame.File, frame.Line)
+		}
+	}
+}
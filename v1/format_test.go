@@ -0,0 +1,122 @@
+package errmgt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestManagedErrorFormat(t *testing.T) {
+	err := NewError(ValidationError, "invalid_email", "Invalid email format")
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"%s", "[validation:invalid_email] Invalid email format"},
+		{"%v", "[validation:invalid_email] Invalid email format"},
+		{"%q", `"[validation:invalid_email] Invalid email format"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, err); got != tt.expected {
+				t.Errorf("Sprintf(%s) = %v, want %v", tt.format, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestManagedErrorFormatVerbose(t *testing.T) {
+	SetIDGenerator(func() string { return "abc123" })
+	defer ResetIDGenerator()
+
+	inner := NewErrorWithCause(ExternalError, "api_timeout", "API timeout", errors.New("connection refused"))
+	err := NewErrorWithCause(SystemError, "sync_failed", "Sync failed", inner).
+		WithContext("table", "users").
+		WithContext("operation", "sync")
+
+	expected := strings.Join([]string{
+		"[system:sync_failed] Sync failed",
+		"    id: abc123",
+		"    context:",
+		"        operation=sync",
+		"        table=users",
+		"caused by: [external:api_timeout] API timeout",
+		"    id: abc123",
+		"caused by: connection refused",
+	}, "\n")
+
+	if got := fmt.Sprintf("%+v", err); got != expected {
+		t.Errorf("Sprintf(%%+v) =\n%s\nwant\n%s", got, expected)
+	}
+}
+
+func TestManagedErrorFormatVerboseStack(t *testing.T) {
+	SetCaptureStack(true)
+	defer SetCaptureStack(false)
+
+	got := fmt.Sprintf("%+v", NewError(SystemError, "db_error", "Database error"))
+	if !strings.Contains(got, "    stack:\n        github.com/kerzzt/go-errmgt.TestManagedErrorFormatVerboseStack") {
+		t.Errorf("Expected stack trace in verbose output, got\n%s", got)
+	}
+}
+
+func TestManagedErrorFormatVerboseCaller(t *testing.T) {
+	err := NewError(SystemError, "db_error", "Database error")
+	err.Caller = "service/user.go:42"
+
+	if got := fmt.Sprintf("%+v", err); !strings.Contains(got, "\n    caller: service/user.go:42") {
+		t.Errorf("Expected caller in verbose output, got\n%s", got)
+	}
+}
+
+func TestSetMaxChainDisplay(t *testing.T) {
+	SetIDGenerator(nil)
+	defer ResetIDGenerator()
+
+	err := NewErrorWithCause(SystemError, "sync_failed", "Sync failed",
+		NewErrorWithCause(ExternalError, "api_timeout", "API timeout",
+			NewErrorWithCause(ExternalError, "dial_failed", "Dial failed", errors.New("connection refused"))))
+
+	SetMaxChainDisplay(1)
+	defer SetMaxChainDisplay(0)
+
+	expected := strings.Join([]string{
+		"[system:sync_failed] Sync failed",
+		"caused by: [external:api_timeout] API timeout",
+		"... (2 more)",
+	}, "\n")
+
+	if got := fmt.Sprintf("%+v", err); got != expected {
+		t.Errorf("Sprintf(%%+v) =\n%s\nwant\n%s", got, expected)
+	}
+
+	SetMaxChainDisplay(3)
+	if got := fmt.Sprintf("%+v", err); !strings.HasSuffix(got, "caused by: connection refused") {
+		t.Errorf("Expected the whole chain within the limit, got\n%s", got)
+	}
+}
+
+func TestSetCodeDelimiter(t *testing.T) {
+	if err := SetCodeDelimiter("/"); err != nil {
+		t.Fatalf("SetCodeDelimiter(/) error: %v", err)
+	}
+	defer SetCodeDelimiter(DefaultCodeDelimiter)
+
+	err := NewError(ValidationError, "invalid_email", "Invalid email").WithDetails("missing @")
+	if got := err.Error(); got != "[validation/invalid_email] Invalid email: missing @" {
+		t.Errorf("Error() = %q", got)
+	}
+
+	for _, delimiter := range []string{"", "[", "]", "]["} {
+		if setErr := SetCodeDelimiter(delimiter); setErr == nil {
+			t.Errorf("Expected SetCodeDelimiter(%q) to fail", delimiter)
+		}
+	}
+
+	if got := err.Error(); got != "[validation/invalid_email] Invalid email: missing @" {
+		t.Errorf("Expected an invalid delimiter to keep the current one, got %q", got)
+	}
+}
@@ -0,0 +1,38 @@
+package errmgt
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+)
+
+var captureGoroutineID atomic.Bool
+
+// SetCaptureGoroutineID enables or disables recording the ID of the
+// goroutine that constructed each error in its GoroutineID field, to help
+// untangle interleaved logs from concurrent code. It is a debugging aid
+// only: Go deliberately does not expose goroutine IDs, so the ID is parsed
+// from a runtime.Stack dump, which is slow and best effort, and IDs are
+// reused once goroutines exit. Leave it disabled, the default, in
+// production.
+func SetCaptureGoroutineID(enabled bool) {
+	captureGoroutineID.Store(enabled)
+}
+
+// goroutineID returns the current goroutine's ID, or 0 if it cannot be
+// parsed
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	// The dump starts with "goroutine 123 [running]:"
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	if i := bytes.IndexByte(buf, ' '); i >= 0 {
+		buf = buf[:i]
+	}
+	id, err := strconv.ParseUint(string(buf), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
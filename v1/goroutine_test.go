@@ -0,0 +1,37 @@
+package errmgt
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSetCaptureGoroutineID(t *testing.T) {
+	if err := NewError(SystemError, "db_error", "Database error"); err.GoroutineID != 0 {
+		t.Errorf("Expected no goroutine ID by default, got %d", err.GoroutineID)
+	}
+
+	SetCaptureGoroutineID(true)
+	defer SetCaptureGoroutineID(false)
+
+	err := NewError(SystemError, "db_error", "Database error")
+	if err.GoroutineID == 0 {
+		t.Fatal("Expected the goroutine ID to be captured")
+	}
+
+	done := make(chan uint64)
+	go func() {
+		done <- NewError(SystemError, "db_error", "Database error").GoroutineID
+	}()
+	if other := <-done; other == 0 || other == err.GoroutineID {
+		t.Errorf("Expected a different goroutine ID from another goroutine, got %d and %d", other, err.GoroutineID)
+	}
+
+	if want := fmt.Sprintf("\n    goroutine: %d", err.GoroutineID); !strings.Contains(fmt.Sprintf("%+v", err), want) {
+		t.Errorf("Expected %%+v to include the goroutine ID, got\n%+v", err)
+	}
+
+	if err.Sanitized().GoroutineID != 0 {
+		t.Error("Expected Sanitized to clear the goroutine ID")
+	}
+}
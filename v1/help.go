@@ -0,0 +1,50 @@
+package errmgt
+
+import (
+	"net/url"
+	"strings"
+	"sync/atomic"
+)
+
+// HelpURLCodePlaceholder is replaced with the error code in the template
+// set with SetHelpURLTemplate
+const HelpURLCodePlaceholder = "{code}"
+
+var helpURLTemplate atomic.Value
+
+// SetHelpURLTemplate sets the template used to generate the HelpURL of new
+// errors from their code, e.g. "https://docs.example.com/errors/{code}".
+// The code is path-escaped before substitution. Errors without a code get
+// no URL, and WithHelpURL overrides the generated one. An empty template,
+// the default, disables generation.
+func SetHelpURLTemplate(template string) {
+	helpURLTemplate.Store(template)
+}
+
+// helpURL expands the help URL template for code
+func helpURL(code Code) string {
+	template, _ := helpURLTemplate.Load().(string)
+	if template == "" || code == "" {
+		return ""
+	}
+	return strings.ReplaceAll(template, HelpURLCodePlaceholder, url.PathEscape(string(code)))
+}
+
+// WithHelpURL sets the URL of documentation describing the error and how
+// to resolve it
+func (e *ManagedError) WithHelpURL(helpURL string) *ManagedError {
+	if e == nil {
+		return nil
+	}
+	e.HelpURL = helpURL
+	return e
+}
+
+// GetHelpURL retrieves the help URL from an error. It returns an empty
+// string when err is not a ManagedError or has no help URL.
+func GetHelpURL(err error) string {
+	if managedErr, ok := asManaged(err); ok {
+		return managedErr.HelpURL
+	}
+	return ""
+}
@@ -0,0 +1,60 @@
+package errmgt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSetHelpURLTemplate(t *testing.T) {
+	if err := NewError(ValidationError, "invalid_email", "Invalid email"); err.HelpURL != "" {
+		t.Errorf("Expected no help URL by default, got '%s'", err.HelpURL)
+	}
+
+	SetHelpURLTemplate("https://docs.example.com/errors/{code}")
+	defer SetHelpURLTemplate("")
+
+	err := NewError(ValidationError, "invalid_email", "Invalid email")
+	if err.HelpURL != "https://docs.example.com/errors/invalid_email" {
+		t.Errorf("Expected generated help URL, got '%s'", err.HelpURL)
+	}
+
+	if err.WithCode("bad email").HelpURL != "https://docs.example.com/errors/bad%20email" {
+		t.Errorf("Expected help URL to follow the code, got '%s'", err.HelpURL)
+	}
+
+	if uncoded := NewError(ValidationError, "", "Invalid email"); uncoded.HelpURL != "" {
+		t.Errorf("Expected no help URL without a code, got '%s'", uncoded.HelpURL)
+	}
+}
+
+func TestManagedErrorWithHelpURL(t *testing.T) {
+	SetHelpURLTemplate("https://docs.example.com/errors/{code}")
+	defer SetHelpURLTemplate("")
+
+	err := NewError(ValidationError, "invalid_email", "Invalid email").
+		WithHelpURL("https://example.com/email-help").
+		WithCode("email_invalid")
+
+	if err.HelpURL != "https://example.com/email-help" {
+		t.Errorf("Expected explicit help URL to be kept, got '%s'", err.HelpURL)
+	}
+
+	if got := GetHelpURL(fmt.Errorf("wrapped: %w", err)); got != err.HelpURL {
+		t.Errorf("GetHelpURL() = '%s', want '%s'", got, err.HelpURL)
+	}
+
+	if got := GetHelpURL(errors.New("plain")); got != "" {
+		t.Errorf("Expected no help URL for a plain error, got '%s'", got)
+	}
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("Failed to marshal: %v", marshalErr)
+	}
+	if !strings.Contains(string(data), `"help_url":"https://example.com/email-help"`) {
+		t.Errorf("Expected help_url in JSON, got %s", data)
+	}
+}
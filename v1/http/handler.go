@@ -0,0 +1,52 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kerzzt/go-errmgt"
+)
+
+// Context keys EnrichRequest stores request details under
+const (
+	MethodKey     = "http_method"
+	PathKey       = "http_path"
+	RemoteAddrKey = "remote_addr"
+)
+
+// EnrichRequest copies the method, URL path and remote address of r into
+// err's context, along with the request ID as FromRequest does. It returns
+// nil for a nil error.
+func EnrichRequest(r *http.Request, err *errmgt.ManagedError) *errmgt.ManagedError {
+	if err == nil {
+		return nil
+	}
+	return FromRequest(r, err).
+		WithContextNonEmpty(MethodKey, r.Method).
+		WithContextNonEmpty(PathKey, r.URL.Path).
+		WithContextNonEmpty(RemoteAddrKey, r.RemoteAddr)
+}
+
+// HandlerFunc is an HTTP handler that reports failures by returning an
+// error. The first ManagedError in the returned error's chain is enriched
+// with EnrichRequest before the error is written with WriteHTTP, so every
+// API error carries the request details. The details are context, which
+// WriteHTTP only sends to clients for keys marked public:
+//
+//	mux.Handle("/users", errhttp.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+//		...
+//	}))
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// ServeHTTP implements http.Handler
+func (f HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	err := f(w, r)
+	if err == nil {
+		return
+	}
+	var managedErr *errmgt.ManagedError
+	if errors.As(err, &managedErr) && managedErr != nil {
+		EnrichRequest(r, managedErr)
+	}
+	WriteHTTP(w, err)
+}
@@ -0,0 +1,76 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kerzzt/go-errmgt"
+)
+
+func TestEnrichRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/users/42?verbose=1", nil)
+	req.Header.Set(DefaultRequestIDHeader, "req-123")
+
+	err := EnrichRequest(req, errmgt.NewError(errmgt.ValidationError, "invalid_email", "Invalid email"))
+
+	for key, want := range map[string]string{
+		MethodKey:     http.MethodPost,
+		PathKey:       "/users/42",
+		RemoteAddrKey: req.RemoteAddr,
+		RequestIDKey:  "req-123",
+	} {
+		if got := err.Context[key]; got != want {
+			t.Errorf("Expected context %s '%s', got '%s'", key, want, got)
+		}
+	}
+
+	if EnrichRequest(req, nil) != nil {
+		t.Error("Expected nil for a nil error")
+	}
+}
+
+func TestHandlerFunc(t *testing.T) {
+	managedErr := errmgt.NewError(errmgt.BusinessError, "user_not_found", "User not found").WithStatusCode(404)
+	handler := HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return errmgt.Wrap(managedErr, "lookup")
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", rec.Code)
+	}
+
+	if managedErr.Context[PathKey] != "/users/42" || managedErr.Context[MethodKey] != http.MethodGet {
+		t.Errorf("Expected the error to be enriched, got %v", managedErr.Context)
+	}
+
+	if strings.Contains(rec.Body.String(), "/users/42") {
+		t.Errorf("Expected request details not to leak into the response, got %s", rec.Body.String())
+	}
+}
+
+func TestHandlerFuncSuccessAndPlainError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("Expected the handler's own response, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return errors.New("secret")
+	}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusInternalServerError || strings.Contains(rec.Body.String(), "secret") {
+		t.Errorf("Expected a generic internal error, got %d %s", rec.Code, rec.Body.String())
+	}
+}
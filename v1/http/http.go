@@ -0,0 +1,72 @@
+// Package http integrates errmgt errors with net/http handlers.
+package http
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/kerzzt/go-errmgt"
+)
+
+// DefaultRequestIDHeader is the header FromRequest and Middleware read the
+// request ID from unless configured otherwise
+const DefaultRequestIDHeader = "X-Request-ID"
+
+// RequestIDKey is the context key request IDs are stored under on errors
+const RequestIDKey = "request_id"
+
+var requestIDHeader atomic.Value
+
+// SetRequestIDHeader changes the header the request ID is read from
+func SetRequestIDHeader(name string) {
+	requestIDHeader.Store(name)
+}
+
+// RequestIDHeader returns the header the request ID is read from
+func RequestIDHeader() string {
+	if name, ok := requestIDHeader.Load().(string); ok && name != "" {
+		return name
+	}
+	return DefaultRequestIDHeader
+}
+
+type requestIDContextKey struct{}
+
+// Middleware stores the request ID header of each request in the request
+// context, where NewFromContext and FromRequest can find it
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id := r.Header.Get(RequestIDHeader()); id != "" {
+			r = r.WithContext(context.WithValue(r.Context(), requestIDContextKey{}, id))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequestID returns the request ID stored in ctx by Middleware
+func RequestID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDContextKey{}).(string)
+	return id, ok && id != ""
+}
+
+// FromRequest copies the request ID of r into err's context. The request
+// ID is taken from the request header, or from the request context when
+// the header is absent.
+func FromRequest(r *http.Request, err *errmgt.ManagedError) *errmgt.ManagedError {
+	id := r.Header.Get(RequestIDHeader())
+	if id == "" {
+		id, _ = RequestID(r.Context())
+	}
+	return err.WithContextNonEmpty(RequestIDKey, id)
+}
+
+// NewFromContext creates a new ManagedError carrying the request ID stored
+// in ctx by Middleware
+func NewFromContext(ctx context.Context, errType errmgt.ErrorType, code errmgt.Code, message string) *errmgt.ManagedError {
+	err := errmgt.NewErrorSkip(1, errType, code, message)
+	if id, ok := RequestID(ctx); ok {
+		err.WithContext(RequestIDKey, id)
+	}
+	return err
+}
@@ -0,0 +1,69 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kerzzt/go-errmgt"
+)
+
+func TestFromRequest(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/users", nil)
+	r.Header.Set("X-Request-ID", "req-123")
+
+	err := FromRequest(r, errmgt.NewError(errmgt.ValidationError, "invalid_input", "Invalid input"))
+	if err.Context[RequestIDKey] != "req-123" {
+		t.Errorf("Expected request ID 'req-123', got '%s'", err.Context[RequestIDKey])
+	}
+}
+
+func TestFromRequestWithoutID(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/users", nil)
+
+	err := FromRequest(r, errmgt.NewError(errmgt.ValidationError, "invalid_input", "Invalid input"))
+	if _, exists := err.Context[RequestIDKey]; exists {
+		t.Error("Expected no request ID without header")
+	}
+}
+
+func TestSetRequestIDHeader(t *testing.T) {
+	SetRequestIDHeader("X-Correlation-ID")
+	defer SetRequestIDHeader(DefaultRequestIDHeader)
+
+	r := httptest.NewRequest(http.MethodGet, "/users", nil)
+	r.Header.Set("X-Request-ID", "ignored")
+	r.Header.Set("X-Correlation-ID", "corr-456")
+
+	err := FromRequest(r, errmgt.NewError(errmgt.ValidationError, "invalid_input", "Invalid input"))
+	if err.Context[RequestIDKey] != "corr-456" {
+		t.Errorf("Expected request ID 'corr-456', got '%s'", err.Context[RequestIDKey])
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	var err *errmgt.ManagedError
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err = NewFromContext(r.Context(), errmgt.BusinessError, "rule", "Rule violated")
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/users", nil)
+	r.Header.Set("X-Request-ID", "req-789")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	if err == nil {
+		t.Fatal("Expected handler to be called")
+	}
+	if err.Context[RequestIDKey] != "req-789" {
+		t.Errorf("Expected request ID 'req-789', got '%s'", err.Context[RequestIDKey])
+	}
+}
+
+func TestNewFromContextWithoutID(t *testing.T) {
+	err := NewFromContext(context.Background(), errmgt.BusinessError, "rule", "Rule violated")
+
+	if _, exists := err.Context[RequestIDKey]; exists {
+		t.Error("Expected no request ID without middleware")
+	}
+}
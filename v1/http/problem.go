@@ -0,0 +1,75 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/kerzzt/go-errmgt"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem documents
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details document
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+var problemTypeBase atomic.Value
+
+// SetProblemTypeBase sets the URI prefix problem types are built from. A
+// ManagedError with code "invalid_email" and base
+// "https://example.com/errors/" gets the type
+// "https://example.com/errors/invalid_email". Without a base the code
+// itself is used as a relative URI reference.
+func SetProblemTypeBase(base string) {
+	problemTypeBase.Store(base)
+}
+
+// NewProblem builds the problem document for err. The help URL, or the
+// code when there is none, determines the type, the message the title,
+// the details the detail and HTTPStatus the status. The request ID
+// recorded by FromRequest, if any, is used as the instance. Errors that
+// are not ManagedErrors produce a generic internal error problem so their
+// messages do not leak to clients.
+func NewProblem(err error) Problem {
+	var managedErr *errmgt.ManagedError
+	if !errors.As(err, &managedErr) || managedErr == nil {
+		return Problem{
+			Type:   "about:blank",
+			Title:  http.StatusText(http.StatusInternalServerError),
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	problem := Problem{
+		Type:     "about:blank",
+		Title:    managedErr.GetMessage(),
+		Status:   managedErr.HTTPStatus(),
+		Detail:   managedErr.Details,
+		Instance: managedErr.Context[RequestIDKey],
+	}
+	if managedErr.HelpURL != "" {
+		problem.Type = managedErr.HelpURL
+	} else if code := managedErr.EffectiveCode(); code != "" {
+		base, _ := problemTypeBase.Load().(string)
+		problem.Type = base + string(code)
+	}
+	return problem
+}
+
+// WriteProblem writes err to w as an RFC 7807 application/problem+json
+// response
+func WriteProblem(w http.ResponseWriter, err error) {
+	problem := NewProblem(err)
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
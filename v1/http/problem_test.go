@@ -0,0 +1,94 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kerzzt/go-errmgt"
+)
+
+func TestWriteProblem(t *testing.T) {
+	SetProblemTypeBase("https://example.com/errors/")
+	defer SetProblemTypeBase("")
+
+	err := errmgt.NewError(errmgt.ValidationError, "invalid_email", "Invalid email format").
+		WithDetails("Email must contain @ symbol").
+		WithContext(RequestIDKey, "req-123").
+		WithStatusCode(http.StatusBadRequest)
+
+	rec := httptest.NewRecorder()
+	WriteProblem(rec, err)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", rec.Code)
+	}
+
+	if ct := rec.Header().Get("Content-Type"); ct != ProblemContentType {
+		t.Errorf("Expected content type %s, got %s", ProblemContentType, ct)
+	}
+
+	var problem Problem
+	if decodeErr := json.NewDecoder(rec.Body).Decode(&problem); decodeErr != nil {
+		t.Fatalf("Failed to decode problem: %v", decodeErr)
+	}
+
+	expected := Problem{
+		Type:     "https://example.com/errors/invalid_email",
+		Title:    "Invalid email format",
+		Status:   http.StatusBadRequest,
+		Detail:   "Email must contain @ symbol",
+		Instance: "req-123",
+	}
+	if problem != expected {
+		t.Errorf("Problem = %+v, want %+v", problem, expected)
+	}
+}
+
+func TestNewProblemHelpURL(t *testing.T) {
+	SetProblemTypeBase("https://example.com/errors/")
+	defer SetProblemTypeBase("")
+
+	err := errmgt.NewError(errmgt.ValidationError, "invalid_email", "Invalid email format").
+		WithHelpURL("https://docs.example.com/errors/invalid_email")
+
+	if problem := NewProblem(err); problem.Type != "https://docs.example.com/errors/invalid_email" {
+		t.Errorf("Expected help URL as type, got '%s'", problem.Type)
+	}
+}
+
+func TestNewProblemDefaults(t *testing.T) {
+	problem := NewProblem(errmgt.NewError(errmgt.SystemError, "db_error", "Database error"))
+
+	if problem.Type != "db_error" {
+		t.Errorf("Expected code as relative type, got '%s'", problem.Type)
+	}
+
+	if problem.Status != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", problem.Status)
+	}
+
+	if problem := NewProblem(errmgt.NewError(errmgt.SystemError, "", "Database error")); problem.Type != "about:blank" {
+		t.Errorf("Expected about:blank type without a code, got '%s'", problem.Type)
+	}
+}
+
+func TestNewProblemAutoCode(t *testing.T) {
+	errmgt.SetAutoCodeFromMessage(true)
+	defer errmgt.SetAutoCodeFromMessage(false)
+
+	if problem := NewProblem(errmgt.NewError(errmgt.SystemError, "", "Database error")); problem.Type != "database_error" {
+		t.Errorf("Expected the derived code as type, got '%s'", problem.Type)
+	}
+}
+
+func TestNewProblemPlainError(t *testing.T) {
+	problem := NewProblem(errors.New("secret database password"))
+
+	expected := Problem{Type: "about:blank", Title: "Internal Server Error", Status: http.StatusInternalServerError}
+	if problem != expected {
+		t.Errorf("Problem = %+v, want %+v", problem, expected)
+	}
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/kerzzt/go-errmgt"
+)
+
+// UnexpectedResponseCode is the code of errors DecodeHTTP returns for
+// responses that do not carry a ManagedError body
+const UnexpectedResponseCode errmgt.Code = "unexpected_response"
+
+// maxErrorBodySize limits how much of an error response DecodeHTTP reads
+const maxErrorBodySize = 1 << 20
+
+// WriteHTTP writes err to w as a JSON response. ManagedErrors are written
+// with their HTTPStatus, which falls back to a status derived from the
+// error type when StatusCode is not set. The body is the error's
+// Sanitized copy, so details, callers, metadata, private context and the
+// cause chain below the nearest boundary are not sent to clients. Other
+// errors are written as a generic internal error so their messages do
+// not leak either. The error is marked with Observe, which reports it to
+// breaker observers when it is attributed to a dependency.
+func WriteHTTP(w http.ResponseWriter, err error) {
+	var managedErr *errmgt.ManagedError
+	if !errors.As(err, &managedErr) || managedErr == nil {
+		managedErr = errmgt.NewError(errmgt.SystemError, "internal_error", http.StatusText(http.StatusInternalServerError))
+	}
+
+	managedErr.Observe()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(managedErr.HTTPStatus())
+	_ = json.NewEncoder(w).Encode(managedErr.Sanitized())
+}
+
+// DecodeHTTP turns an error response written by WriteHTTP back into a
+// ManagedError. It returns nil for 2xx responses. Responses whose body is
+// not a ManagedError are returned as an ExternalError with code
+// UnexpectedResponseCode, carrying the status code and the raw body as
+// details. DecodeHTTP reads but does not close the response body.
+func DecodeHTTP(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	body, readErr := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if readErr != nil {
+		return errmgt.NewErrorWithCause(errmgt.ExternalError, UnexpectedResponseCode, "Failed to read error response", readErr).
+			WithStatusCode(resp.StatusCode)
+	}
+
+	var managedErr errmgt.ManagedError
+	if err := json.Unmarshal(body, &managedErr); err == nil && managedErr.Type != "" {
+		if managedErr.StatusCode == 0 {
+			managedErr.StatusCode = resp.StatusCode
+		}
+		return &managedErr
+	}
+
+	return errmgt.NewError(errmgt.ExternalError, UnexpectedResponseCode, http.StatusText(resp.StatusCode)).
+		WithDetails(string(body)).
+		WithStatusCode(resp.StatusCode)
+}
@@ -0,0 +1,158 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kerzzt/go-errmgt"
+)
+
+func TestWriteHTTP(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteHTTP(rec, errmgt.NewError(errmgt.ValidationError, "invalid_email", "Invalid email").WithStatusCode(400))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", rec.Code)
+	}
+
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected JSON content type, got '%s'", ct)
+	}
+
+	if !strings.Contains(rec.Body.String(), `"code":"invalid_email"`) {
+		t.Errorf("Expected error code in body, got %s", rec.Body.String())
+	}
+}
+
+func TestWriteHTTPDefaultStatus(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteHTTP(rec, errmgt.NewError(errmgt.ExternalError, "upstream_failed", "Upstream failed"))
+
+	if rec.Code != http.StatusBadGateway {
+		t.Errorf("Expected status 502, got %d", rec.Code)
+	}
+}
+
+func TestWriteHTTPPlainError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteHTTP(rec, errors.New("secret database password"))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", rec.Code)
+	}
+
+	if strings.Contains(rec.Body.String(), "secret") {
+		t.Errorf("Expected plain error message not to leak, got %s", rec.Body.String())
+	}
+}
+
+func TestWriteHTTPSanitizes(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteHTTP(rec, errmgt.NewErrorWithCause(errmgt.SystemError, "db_failed", "Database failed", errors.New("secret dsn")).
+		WithDetails("secret query").
+		WithMetadata(map[string]interface{}{"secret": "metadata"}).
+		WithContext("secret_key", "secret value").
+		WithContext("user_id", "42").
+		WithPublicContextKeys("user_id"))
+
+	body := rec.Body.String()
+	if strings.Contains(body, "secret") {
+		t.Errorf("Expected internal details not to leak, got %s", body)
+	}
+
+	if !strings.Contains(body, `"user_id":"42"`) {
+		t.Errorf("Expected public context in the response, got %s", body)
+	}
+}
+
+type countingObserver struct {
+	count int
+}
+
+func (o *countingObserver) Observe(error) {
+	o.count++
+}
+
+func TestWriteHTTPObserves(t *testing.T) {
+	observer := &countingObserver{}
+	defer errmgt.RegisterBreakerObserver(observer)()
+
+	err := errmgt.NewError(errmgt.ExternalError, "api_timeout", "API timeout").WithDependency("payments-api")
+	WriteHTTP(httptest.NewRecorder(), err)
+
+	if err.ObservedAt.IsZero() {
+		t.Error("Expected the written error to be observed")
+	}
+
+	if observer.count != 1 {
+		t.Errorf("Expected the dependency error to be reported once, got %d", observer.count)
+	}
+}
+
+func TestDecodeHTTP(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteHTTP(rec, errmgt.NewError(errmgt.ExternalError, "api_timeout", "API timeout").
+		WithRetryable(true).
+		WithStatusCode(504))
+
+	err := DecodeHTTP(rec.Result())
+
+	var managedErr *errmgt.ManagedError
+	if !errors.As(err, &managedErr) {
+		t.Fatalf("Expected a ManagedError, got %v", err)
+	}
+
+	if managedErr.Type != errmgt.ExternalError || managedErr.Code != "api_timeout" {
+		t.Errorf("Expected external api_timeout error, got %v", managedErr)
+	}
+
+	if managedErr.Message != "API timeout" {
+		t.Errorf("Expected message 'API timeout', got '%s'", managedErr.Message)
+	}
+
+	if managedErr.StatusCode != 504 {
+		t.Errorf("Expected status code 504, got %d", managedErr.StatusCode)
+	}
+
+	if !managedErr.Retryable {
+		t.Error("Expected error to be retryable")
+	}
+}
+
+func TestDecodeHTTPSuccess(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}"))}
+
+	if err := DecodeHTTP(resp); err != nil {
+		t.Errorf("Expected nil for 2xx response, got %v", err)
+	}
+}
+
+func TestDecodeHTTPForeignBody(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusBadGateway,
+		Body:       io.NopCloser(strings.NewReader("<html>Bad Gateway</html>")),
+	}
+
+	err := DecodeHTTP(resp)
+
+	var managedErr *errmgt.ManagedError
+	if !errors.As(err, &managedErr) {
+		t.Fatalf("Expected a ManagedError, got %v", err)
+	}
+
+	if managedErr.Type != errmgt.ExternalError || managedErr.Code != UnexpectedResponseCode {
+		t.Errorf("Expected external unexpected_response error, got %v", managedErr)
+	}
+
+	if managedErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("Expected status code 502, got %d", managedErr.StatusCode)
+	}
+
+	if managedErr.Details != "<html>Bad Gateway</html>" {
+		t.Errorf("Expected raw body in details, got '%s'", managedErr.Details)
+	}
+}
@@ -0,0 +1,55 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kerzzt/go-errmgt"
+)
+
+// FromStatusCode creates a ManagedError for an HTTP status code, typically
+// one returned by a server that sent no usable error body. The type is
+// derived from the status:
+//
+//   - 400 is a ValidationError and other 4xx statuses are BusinessErrors
+//   - 502, 503 and 504 are ExternalErrors, since they report a failing
+//     upstream, and other 5xx statuses are SystemErrors
+//   - anything else is a SystemError
+//
+// The code is the status text in snake_case, such as "not_found", or
+// "http_<status>" for statuses without one. StatusCode is set, and 5xx and
+// 429 errors are retryable. An empty message defaults to the status text.
+func FromStatusCode(status int, message string) *errmgt.ManagedError {
+	if message == "" {
+		message = http.StatusText(status)
+	}
+	return errmgt.NewErrorSkip(1, statusType(status), statusCode(status), message).
+		WithStatusCode(status).
+		WithRetryable(status >= 500 && status < 600 || status == http.StatusTooManyRequests)
+}
+
+// statusType returns the ErrorType FromStatusCode uses for status
+func statusType(status int) errmgt.ErrorType {
+	switch {
+	case status == http.StatusBadRequest:
+		return errmgt.ValidationError
+	case status >= 400 && status < 500:
+		return errmgt.BusinessError
+	case status == http.StatusBadGateway || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout:
+		return errmgt.ExternalError
+	default:
+		return errmgt.SystemError
+	}
+}
+
+// statusCode returns the error code FromStatusCode uses for status
+func statusCode(status int) errmgt.Code {
+	text := http.StatusText(status)
+	if text == "" {
+		return errmgt.Code(fmt.Sprintf("http_%d", status))
+	}
+	return errmgt.Code(strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	}), "_"))
+}
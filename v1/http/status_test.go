@@ -0,0 +1,61 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/kerzzt/go-errmgt"
+)
+
+func TestFromStatusCode(t *testing.T) {
+	tests := []struct {
+		status    int
+		errType   errmgt.ErrorType
+		code      errmgt.Code
+		retryable bool
+	}{
+		{http.StatusBadRequest, errmgt.ValidationError, "bad_request", false},
+		{http.StatusNotFound, errmgt.BusinessError, "not_found", false},
+		{http.StatusUnprocessableEntity, errmgt.BusinessError, "unprocessable_entity", false},
+		{http.StatusTooManyRequests, errmgt.BusinessError, "too_many_requests", true},
+		{http.StatusTeapot, errmgt.BusinessError, "i_m_a_teapot", false},
+		{http.StatusInternalServerError, errmgt.SystemError, "internal_server_error", true},
+		{http.StatusBadGateway, errmgt.ExternalError, "bad_gateway", true},
+		{http.StatusServiceUnavailable, errmgt.ExternalError, "service_unavailable", true},
+		{http.StatusGatewayTimeout, errmgt.ExternalError, "gateway_timeout", true},
+		{599, errmgt.SystemError, "http_599", true},
+		{http.StatusFound, errmgt.SystemError, "found", false},
+	}
+
+	for _, tt := range tests {
+		err := FromStatusCode(tt.status, "")
+
+		if err.Type != tt.errType {
+			t.Errorf("FromStatusCode(%d) type = %s, want %s", tt.status, err.Type, tt.errType)
+		}
+
+		if err.Code != tt.code {
+			t.Errorf("FromStatusCode(%d) code = %s, want %s", tt.status, err.Code, tt.code)
+		}
+
+		if err.Retryable != tt.retryable {
+			t.Errorf("FromStatusCode(%d) retryable = %v, want %v", tt.status, err.Retryable, tt.retryable)
+		}
+
+		if err.StatusCode != tt.status {
+			t.Errorf("FromStatusCode(%d) status = %d", tt.status, err.StatusCode)
+		}
+
+		if err.Message != http.StatusText(tt.status) {
+			t.Errorf("FromStatusCode(%d) message = %q, want the status text", tt.status, err.Message)
+		}
+	}
+}
+
+func TestFromStatusCodeMessage(t *testing.T) {
+	err := FromStatusCode(http.StatusNotFound, "User 42 not found")
+
+	if err.Message != "User 42 not found" {
+		t.Errorf("Expected the given message, got %q", err.Message)
+	}
+}
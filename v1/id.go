@@ -0,0 +1,60 @@
+package errmgt
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math/rand/v2"
+	"sync/atomic"
+)
+
+// idGenerator holds the configured generator; a nil fn disables IDs
+type idGenerator struct {
+	fn func() string
+}
+
+var customIDGenerator atomic.Pointer[idGenerator]
+
+// SetIDGenerator replaces the function used to assign an ID to every new
+// error. Passing nil disables ID generation. By default each error gets a
+// random 16 character hex ID, which is only formatted when it is first
+// needed; see GetID.
+func SetIDGenerator(generate func() string) {
+	customIDGenerator.Store(&idGenerator{fn: generate})
+}
+
+// ResetIDGenerator restores the default ID generator
+func ResetIDGenerator() {
+	customIDGenerator.Store(nil)
+}
+
+// newID returns the ID of a new error from a custom generator, or for the
+// default generator the random seed GetID formats the ID from, so errors
+// whose ID is never read do not allocate one. Both are zero when IDs are
+// disabled. The seed is not cryptographically secure, which keeps it
+// cheap; IDs only need to be unique enough to find an error in the logs.
+func newID() (string, uint64) {
+	if custom := customIDGenerator.Load(); custom != nil {
+		if custom.fn == nil {
+			return "", 0
+		}
+		return custom.fn(), 0
+	}
+	return "", rand.Uint64()
+}
+
+// GetID returns the error's ID. IDs from a custom generator, and IDs
+// decoded from JSON, are stored in the ID field. The default random ID is
+// formatted on each call instead, so the field stays empty for it; JSON
+// encoding, %+v and the other renderings use GetID. It returns an empty
+// string for a nil error.
+func (e *ManagedError) GetID() string {
+	if e == nil {
+		return ""
+	}
+	if e.ID != "" || e.idSeed == 0 {
+		return e.ID
+	}
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], e.idSeed)
+	return hex.EncodeToString(buf[:])
+}
@@ -0,0 +1,83 @@
+package errmgt
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var hexID = regexp.MustCompile(`^[0-9a-f]{16}$`)
+
+func TestNewErrorID(t *testing.T) {
+	first := NewError(ValidationError, "invalid_input", "Invalid input")
+	second := NewError(ValidationError, "invalid_input", "Invalid input")
+
+	if !hexID.MatchString(first.GetID()) {
+		t.Errorf("Expected a 16 character hex ID, got '%s'", first.GetID())
+	}
+
+	if first.GetID() == second.GetID() {
+		t.Error("Expected errors to get distinct IDs")
+	}
+}
+
+func TestSetIDGenerator(t *testing.T) {
+	defer ResetIDGenerator()
+
+	SetIDGenerator(func() string { return "fixed-id" })
+	if id := NewError(ValidationError, "invalid_input", "Invalid input").GetID(); id != "fixed-id" {
+		t.Errorf("Expected custom ID, got '%s'", id)
+	}
+
+	SetIDGenerator(nil)
+	if id := NewError(ValidationError, "invalid_input", "Invalid input").GetID(); id != "" {
+		t.Errorf("Expected no ID when disabled, got '%s'", id)
+	}
+
+	ResetIDGenerator()
+	if id := NewError(ValidationError, "invalid_input", "Invalid input").GetID(); !hexID.MatchString(id) {
+		t.Errorf("Expected default ID after reset, got '%s'", id)
+	}
+}
+
+func TestGetIDDefault(t *testing.T) {
+	err := NewError(ValidationError, "invalid_input", "Invalid input")
+	id := err.GetID()
+
+	if err.ID != "" {
+		t.Errorf("Expected the default ID not to be stored, got '%s'", err.ID)
+	}
+
+	if err.GetID() != id || err.clone().GetID() != id {
+		t.Error("Expected the ID to be stable across calls and copies")
+	}
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("Failed to marshal error: %v", marshalErr)
+	}
+	if !strings.Contains(string(data), `"id":"`+id+`"`) {
+		t.Errorf("Expected the ID in JSON, got %s", data)
+	}
+
+	if !strings.Contains(fmt.Sprintf("%+v", err), "id: "+id) {
+		t.Errorf("Expected the ID in %%+v, got %+v", err)
+	}
+
+	var decoded ManagedError
+	if unmarshalErr := json.Unmarshal(data, &decoded); unmarshalErr != nil || decoded.GetID() != id {
+		t.Errorf("Expected the ID to survive decoding, got '%s', %v", decoded.GetID(), unmarshalErr)
+	}
+}
+
+func BenchmarkNewErrorWithoutID(b *testing.B) {
+	SetIDGenerator(nil)
+	defer ResetIDGenerator()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = NewError(ValidationError, "invalid_input", "Input validation failed")
+	}
+}
@@ -0,0 +1,207 @@
+package errmgt
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync/atomic"
+)
+
+var includeStackInJSON atomic.Bool
+
+// SetIncludeStackInJSON controls whether MarshalJSON includes the captured
+// stack trace under "stack", as "file:line:function" entries. It is
+// disabled by default because stack traces should not reach production
+// clients; Error() never includes the stack.
+func SetIncludeStackInJSON(enabled bool) {
+	includeStackInJSON.Store(enabled)
+}
+
+// managedErrorJSON has the same fields as ManagedError but none of its
+// methods, so it can be encoded without recursing into MarshalJSON.
+type managedErrorJSON ManagedError
+
+// causeJSON is a single entry of the serialized cause chain
+type causeJSON struct {
+	Type    ErrorType `json:"type,omitempty"`
+	Code    Code      `json:"code,omitempty"`
+	Message string    `json:"message"`
+}
+
+// errorJSON is the wire representation of a ManagedError. Its Details
+// field shadows the embedded one so details can be a string or an array.
+type errorJSON struct {
+	*managedErrorJSON
+	Details    json.RawMessage `json:"details,omitempty"`
+	Stack      []string        `json:"stack,omitempty"`
+	CauseChain []causeJSON     `json:"cause_chain,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler. The Cause field is not encoded
+// directly; instead each error down its Unwrap chain is serialized in
+// cause_chain, with the type and code of any ManagedErrors. Details made
+// of several lines are encoded as an array, the stack trace is only
+// included when enabled with SetIncludeStackInJSON, and the context is
+// limited to the keys set with SetPropagatableContextKeys.
+//
+// The output is stable across runs: fields appear in declaration order,
+// context and metadata map keys are sorted, and tags and fingerprints keep
+// the order they were added in. A nil error encodes as null.
+func (e *ManagedError) MarshalJSON() ([]byte, error) {
+	if e == nil {
+		return []byte("null"), nil
+	}
+	e.GetMessage()
+	encoded := errorJSON{
+		managedErrorJSON: (*managedErrorJSON)(e),
+		CauseChain:       causeChain(e.Cause),
+	}
+	context, code, id := e.propagatedContext(), e.EffectiveCode(), e.GetID()
+	if len(context) != len(e.Context) || code != e.Code || id != e.ID {
+		filtered := managedErrorJSON(*e)
+		filtered.Context = context
+		filtered.Code = code
+		filtered.ID = id
+		encoded.managedErrorJSON = &filtered
+	}
+	if lines := e.DetailLines(); len(lines) > 1 {
+		encoded.Details, _ = json.Marshal(lines)
+	} else if e.Details != "" {
+		encoded.Details, _ = json.Marshal(e.Details)
+	}
+	if includeStackInJSON.Load() {
+		for _, frame := range e.StackTrace() {
+			encoded.Stack = append(encoded.Stack, fmt.Sprintf("%s:%d:%s", frame.File, frame.Line, frame.Function))
+		}
+	}
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. When a cause chain is present
+// it is rebuilt as Cause, so ManagedErrors in the chain still match with
+// errors.Is.
+func (e *ManagedError) UnmarshalJSON(data []byte) error {
+	decoded := errorJSON{managedErrorJSON: &managedErrorJSON{}}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if len(decoded.Context) == 0 {
+		decoded.Context = nil
+	}
+	*e = ManagedError(*decoded.managedErrorJSON)
+	e.Cause = rebuildCauseChain(decoded.CauseChain)
+	return e.decodeDetails(decoded.Details)
+}
+
+// decodeDetails sets the details from their string or array encoding
+func (e *ManagedError) decodeDetails(data json.RawMessage) error {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	if data[0] != '[' {
+		return json.Unmarshal(data, &e.Details)
+	}
+	var lines []string
+	if err := json.Unmarshal(data, &lines); err != nil {
+		return err
+	}
+	for _, line := range lines {
+		e.WithDetailLine(line)
+	}
+	return nil
+}
+
+// StreamJSON writes err to w as a JSON array of error objects, encoding
+// one error at a time so memory stays bounded for very large MultiErrors.
+// The errors of a MultiError, including those of nested MultiErrors, are
+// written in order; any other error is written as a one-element array and
+// nil as an empty array. ManagedErrors are encoded as by MarshalJSON and
+// other errors as an object holding their message.
+func StreamJSON(w io.Writer, err error) error {
+	if _, writeErr := io.WriteString(w, "["); writeErr != nil {
+		return writeErr
+	}
+	first := true
+	if streamErr := streamJSONElements(w, err, &first); streamErr != nil {
+		return streamErr
+	}
+	_, writeErr := io.WriteString(w, "]")
+	return writeErr
+}
+
+// streamJSONElements writes the elements of err's array, preceded by a
+// comma unless *first is set
+func streamJSONElements(w io.Writer, err error, first *bool) error {
+	if err == nil {
+		return nil
+	}
+	if multi, ok := err.(*MultiError); ok {
+		for _, child := range multi.Errors {
+			if streamErr := streamJSONElements(w, child, first); streamErr != nil {
+				return streamErr
+			}
+		}
+		return nil
+	}
+
+	var element interface{} = causeJSON{Message: err.Error()}
+	if managedErr, ok := err.(*ManagedError); ok {
+		element = managedErr
+	}
+	data, marshalErr := json.Marshal(element)
+	if marshalErr != nil {
+		return marshalErr
+	}
+	if !*first {
+		if _, writeErr := io.WriteString(w, ","); writeErr != nil {
+			return writeErr
+		}
+	}
+	*first = false
+	_, writeErr := w.Write(data)
+	return writeErr
+}
+
+func causeChain(err error) []causeJSON {
+	var chain []causeJSON
+	walkChain(err, func(e error) {
+		if managedErr, ok := e.(*ManagedError); ok {
+			chain = append(chain, causeJSON{Type: managedErr.Type, Code: managedErr.EffectiveCode(), Message: managedErr.GetMessage()})
+		} else {
+			chain = append(chain, causeJSON{Message: e.Error()})
+		}
+	})
+	return chain
+}
+
+func rebuildCauseChain(chain []causeJSON) error {
+	var cause error
+	for i := len(chain) - 1; i >= 0; i-- {
+		c := chain[i]
+		if c.Type != "" || c.Code != "" {
+			cause = &ManagedError{
+				Type:    c.Type,
+				Code:    c.Code,
+				Message: c.Message,
+				Cause:   cause,
+			}
+		} else {
+			cause = &decodedError{message: c.Message, cause: cause}
+		}
+	}
+	return cause
+}
+
+// decodedError is a plain error rebuilt from a serialized cause chain
+type decodedError struct {
+	message string
+	cause   error
+}
+
+func (d *decodedError) Error() string {
+	return d.message
+}
+
+func (d *decodedError) Unwrap() error {
+	return d.cause
+}
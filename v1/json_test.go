@@ -0,0 +1,320 @@
+package errmgt
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func roundTripJSON(t *testing.T, err *ManagedError) *ManagedError {
+	t.Helper()
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("Failed to marshal error: %v", marshalErr)
+	}
+	var decoded ManagedError
+	if unmarshalErr := json.Unmarshal(data, &decoded); unmarshalErr != nil {
+		t.Fatalf("Failed to unmarshal error: %v", unmarshalErr)
+	}
+	return &decoded
+}
+
+// publicFields returns a copy of err without the fields that are not
+// serialized, with its default ID formatted into the ID field.
+func publicFields(err *ManagedError) ManagedError {
+	out := *err
+	out.ID = err.GetID()
+	out.idSeed = 0
+	out.Cause = nil
+	out.stack = nil
+	out.publicContextKeys = nil
+	return out
+}
+
+func TestManagedErrorJSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *ManagedError
+	}{
+		{
+			name: "empty context",
+			err:  NewError(ValidationError, "invalid_email", "Invalid email format"),
+		},
+		{
+			name: "zero status code",
+			err:  NewError(BusinessError, "rule", "Rule violated").WithStatusCode(0),
+		},
+		{
+			name: "all fields",
+			err: NewErrorWithCause(ExternalError, "api_timeout", "API timeout", errors.New("cause")).
+				WithDetails("upstream did not respond").
+				WithUserMessage("Try again later").
+				WithContext("endpoint", "/users").
+				WithRetryable(true).
+				WithRetryAfter(time.Second).
+				WithStatusCode(504),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded := roundTripJSON(t, tt.err)
+			if want := publicFields(tt.err); !reflect.DeepEqual(publicFields(decoded), want) {
+				t.Errorf("Round trip = %+v, want %+v", publicFields(decoded), want)
+			}
+		})
+	}
+}
+
+func TestManagedErrorUnmarshalJSONInvalid(t *testing.T) {
+	var decoded ManagedError
+	if err := json.Unmarshal([]byte(`{"code": 1}`), &decoded); err == nil {
+		t.Error("Expected error for invalid JSON shape")
+	}
+}
+
+func FuzzErrorJSONRoundTrip(f *testing.F) {
+	f.Add("validation", "invalid_email", "Invalid email", "", "", "", "", 0, false, int64(0))
+	f.Add("external", "api_timeout", "API timeout", "details", "Try again", "endpoint", "/users", 504, true, int64(time.Second))
+	f.Add("", "", "", "", "", "", "", -1, false, int64(-1))
+
+	f.Fuzz(func(t *testing.T, errType, code, message, details, userMessage, key, value string,
+		statusCode int, retryable bool, retryAfter int64) {
+		// JSON strings cannot carry invalid UTF-8; encoding/json replaces it
+		for _, s := range []string{errType, code, message, details, userMessage, key, value} {
+			if !utf8.ValidString(s) {
+				t.Skip()
+			}
+		}
+
+		err := NewError(ErrorType(errType), Code(code), message).
+			WithDetails(details).
+			WithUserMessage(userMessage).
+			WithRetryable(retryable).
+			WithRetryAfter(time.Duration(retryAfter)).
+			WithStatusCode(statusCode)
+		if key != "" {
+			err.WithContext(key, value)
+		}
+
+		decoded := roundTripJSON(t, err)
+		if want := publicFields(err); !reflect.DeepEqual(publicFields(decoded), want) {
+			t.Errorf("Round trip = %+v, want %+v", publicFields(decoded), want)
+		}
+	})
+}
+
+func TestManagedErrorJSONCauseChain(t *testing.T) {
+	root := errors.New("connection refused")
+	inner := NewErrorWithCause(ExternalError, "api_unavailable", "API unavailable", Wrap(root, "dialing"))
+	err := NewErrorWithCause(SystemError, "sync_failed", "Sync failed", inner)
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("Failed to marshal error: %v", marshalErr)
+	}
+
+	var decoded struct {
+		CauseChain []map[string]string `json:"cause_chain"`
+	}
+	if unmarshalErr := json.Unmarshal(data, &decoded); unmarshalErr != nil {
+		t.Fatalf("Failed to unmarshal error: %v", unmarshalErr)
+	}
+
+	expected := []map[string]string{
+		{"type": "external", "code": "api_unavailable", "message": "API unavailable"},
+		{"message": "dialing: connection refused"},
+		{"message": "connection refused"},
+	}
+	if !reflect.DeepEqual(decoded.CauseChain, expected) {
+		t.Errorf("Cause chain = %v, want %v", decoded.CauseChain, expected)
+	}
+}
+
+func TestManagedErrorJSONCauseChainRoundTrip(t *testing.T) {
+	inner := NewErrorWithCause(ExternalError, "api_unavailable", "API unavailable", errors.New("connection refused"))
+	err := NewErrorWithCause(SystemError, "sync_failed", "Sync failed", inner)
+
+	decoded := roundTripJSON(t, err)
+
+	if !errors.Is(decoded, NewError(ExternalError, "api_unavailable", "")) {
+		t.Error("Expected decoded error to match the ManagedError in its cause chain")
+	}
+
+	if root := errors.Unwrap(decoded.Cause); root == nil || root.Error() != "connection refused" {
+		t.Errorf("Expected root cause 'connection refused', got %v", root)
+	}
+
+	first, _ := json.Marshal(err)
+	second, _ := json.Marshal(decoded)
+	if string(first) != string(second) {
+		t.Errorf("Expected re-encoded JSON to match\nfirst:  %s\nsecond: %s", first, second)
+	}
+}
+
+func TestManagedErrorJSONDetailLines(t *testing.T) {
+	single := NewError(ValidationError, "invalid_order", "Invalid order").WithDetailLine("quantity must be positive")
+	data, err := json.Marshal(single)
+	if err != nil {
+		t.Fatalf("Failed to marshal error: %v", err)
+	}
+	if !strings.Contains(string(data), `"details":"quantity must be positive"`) {
+		t.Errorf("Expected a single line as a string, got %s", data)
+	}
+
+	multiple := NewError(ValidationError, "invalid_order", "Invalid order").
+		WithDetailLine("quantity must be positive").
+		WithDetailLine("currency is not supported")
+	data, err = json.Marshal(multiple)
+	if err != nil {
+		t.Fatalf("Failed to marshal error: %v", err)
+	}
+	if !strings.Contains(string(data), `"details":["quantity must be positive","currency is not supported"]`) {
+		t.Errorf("Expected several lines as an array, got %s", data)
+	}
+
+	decoded := roundTripJSON(t, multiple)
+	if want := publicFields(multiple); !reflect.DeepEqual(publicFields(decoded), want) {
+		t.Errorf("Round trip = %+v, want %+v", publicFields(decoded), want)
+	}
+}
+
+func TestManagedErrorJSONStable(t *testing.T) {
+	SetIDGenerator(nil)
+	defer ResetIDGenerator()
+
+	newErr := func() *ManagedError {
+		err := NewErrorWithCause(ExternalError, "api_timeout", "API timeout", errors.New("connection refused")).
+			WithContext("zone", "eu-1").
+			WithContext("endpoint", "/users").
+			WithContext("attempt", "3").
+			WithContext("method", "GET").
+			WithTags("user_facing", "billing").
+			WithMetadata(map[string]interface{}{"limit": 10, "cursor": "abc", "filter": map[string]string{"b": "2", "a": "1"}})
+		err.Timestamp = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+		return err
+	}
+
+	expected := `{"code":"api_timeout","message":"API timeout",` +
+		`"context":{"attempt":"3","endpoint":"/users","method":"GET","zone":"eu-1"},` +
+		`"type":"external","retryable":false,"tags":["user_facing","billing"],` +
+		`"metadata":{"cursor":"abc","filter":{"a":"1","b":"2"},"limit":10},` +
+		`"timestamp":"2024-03-01T12:00:00Z",` +
+		`"cause_chain":[{"message":"connection refused"}]}`
+
+	for i := 0; i < 20; i++ {
+		data, err := json.Marshal(newErr())
+		if err != nil {
+			t.Fatalf("Failed to marshal error: %v", err)
+		}
+		if string(data) != expected {
+			t.Fatalf("JSON output =\n%s\nwant\n%s", data, expected)
+		}
+	}
+}
+
+func TestStreamJSON(t *testing.T) {
+	SetIDGenerator(nil)
+	defer ResetIDGenerator()
+
+	err := Append(
+		NewError(ValidationError, "invalid_email", "Invalid email"),
+		Append(errors.New("row 2: malformed"), NewError(ValidationError, "invalid_name", "Invalid name")),
+	)
+
+	var buf strings.Builder
+	if streamErr := StreamJSON(&buf, err); streamErr != nil {
+		t.Fatalf("StreamJSON() error: %v", streamErr)
+	}
+
+	var decoded []map[string]interface{}
+	if unmarshalErr := json.Unmarshal([]byte(buf.String()), &decoded); unmarshalErr != nil {
+		t.Fatalf("Expected a JSON array, got %s: %v", buf.String(), unmarshalErr)
+	}
+
+	if len(decoded) != 3 {
+		t.Fatalf("Expected 3 elements, got %d: %s", len(decoded), buf.String())
+	}
+
+	if decoded[0]["code"] != "invalid_email" || decoded[1]["message"] != "row 2: malformed" || decoded[2]["code"] != "invalid_name" {
+		t.Errorf("Unexpected elements: %s", buf.String())
+	}
+}
+
+func TestStreamJSONSingleAndNil(t *testing.T) {
+	SetIDGenerator(nil)
+	defer ResetIDGenerator()
+
+	managedErr := NewError(SystemError, "db_error", "Database error")
+	managedErr.Timestamp = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	var buf strings.Builder
+	if err := StreamJSON(&buf, managedErr); err != nil {
+		t.Fatalf("StreamJSON() error: %v", err)
+	}
+	if expected := `[{"code":"db_error","message":"Database error","type":"system","retryable":false,"timestamp":"2024-03-01T12:00:00Z"}]`; buf.String() != expected {
+		t.Errorf("StreamJSON() = %s, want %s", buf.String(), expected)
+	}
+
+	buf.Reset()
+	if err := StreamJSON(&buf, nil); err != nil || buf.String() != "[]" {
+		t.Errorf("StreamJSON(nil) = %s, %v, want [], nil", buf.String(), err)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestStreamJSONWriteError(t *testing.T) {
+	if err := StreamJSON(failingWriter{}, errors.New("failure")); err == nil || err.Error() != "disk full" {
+		t.Errorf("Expected the write error, got %v", err)
+	}
+}
+
+func TestSetIncludeStackInJSON(t *testing.T) {
+	SetCaptureStack(true)
+	defer SetCaptureStack(false)
+
+	err := NewError(SystemError, "db_error", "Database error")
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("Failed to marshal error: %v", marshalErr)
+	}
+	if strings.Contains(string(data), `"stack"`) {
+		t.Errorf("Expected no stack by default, got %s", data)
+	}
+
+	SetIncludeStackInJSON(true)
+	defer SetIncludeStackInJSON(false)
+
+	data, marshalErr = json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("Failed to marshal error: %v", marshalErr)
+	}
+	var decoded struct {
+		Stack []string `json:"stack"`
+	}
+	if unmarshalErr := json.Unmarshal(data, &decoded); unmarshalErr != nil {
+		t.Fatalf("Failed to unmarshal error: %v", unmarshalErr)
+	}
+	if len(decoded.Stack) == 0 || !strings.Contains(decoded.Stack[0], "json_test.go:") ||
+		!strings.HasSuffix(decoded.Stack[0], ":github.com/kerzzt/go-errmgt.TestSetIncludeStackInJSON") {
+		t.Errorf("Expected file:line:function stack entries, got %v", decoded.Stack)
+	}
+
+	if strings.Contains(err.Error(), "json_test.go") {
+		t.Errorf("Expected Error() never to include the stack, got %s", err.Error())
+	}
+
+	if decodedErr := roundTripJSON(t, err); decodedErr.Code != "db_error" {
+		t.Errorf("Expected an error with a stack to decode, got %v", decodedErr)
+	}
+}
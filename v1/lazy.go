@@ -0,0 +1,35 @@
+package errmgt
+
+import "sync"
+
+// lazyMessage computes the message of an error created with NewLazy once,
+// on first use
+type lazyMessage struct {
+	once    sync.Once
+	compute func() string
+}
+
+// NewLazy creates a new ManagedError whose message is computed by compute
+// the first time it is needed, for messages that are expensive to build
+// and would be wasted on errors dropped by sampling or severity
+// thresholds. The result is cached. Error, UserError, GetMessage, JSON
+// encoding and logging compute it; until then the Message field is empty.
+func NewLazy(errType ErrorType, code Code, compute func() string) *ManagedError {
+	e := newError(1, errType, code, "", nil)
+	e.lazyMessage = &lazyMessage{compute: compute}
+	return e
+}
+
+// GetMessage returns the error's Message, computing it first for errors
+// created with NewLazy. It returns an empty string for a nil error.
+func (e *ManagedError) GetMessage() string {
+	if e == nil {
+		return ""
+	}
+	if lazy := e.lazyMessage; lazy != nil {
+		lazy.once.Do(func() {
+			e.Message = lazy.compute()
+		})
+	}
+	return e.Message
+}
@@ -0,0 +1,102 @@
+package errmgt
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewLazy(t *testing.T) {
+	var calls atomic.Int32
+	err := NewLazy(SystemError, "sync_failed", func() string {
+		calls.Add(1)
+		return "Sync failed for 1000 records"
+	})
+
+	if calls.Load() != 0 {
+		t.Fatal("Expected the message not to be computed on creation")
+	}
+
+	if err.Error() != "[system:sync_failed] Sync failed for 1000 records" {
+		t.Errorf("Unexpected Error() output: %s", err.Error())
+	}
+
+	if err.GetMessage() != "Sync failed for 1000 records" || err.UserError() != "Sync failed for 1000 records" {
+		t.Errorf("Unexpected message: %q", err.GetMessage())
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("Expected the message to be computed once, got %d calls", calls.Load())
+	}
+}
+
+func TestNewLazyDropped(t *testing.T) {
+	NewLazy(SystemError, "sync_failed", func() string {
+		t.Error("Expected the message of a discarded error not to be computed")
+		return ""
+	})
+}
+
+func TestNewLazySampled(t *testing.T) {
+	s := NewSampler(1, time.Minute)
+	d := NewDeduplicator(time.Minute)
+	first := NewError(SystemError, "sync_failed", "Sync failed")
+	if !s.Allow(first) || d.Seen(first) {
+		t.Fatal("Expected the first error to be let through")
+	}
+
+	err := NewLazy(SystemError, "sync_failed", func() string {
+		t.Error("Expected the message of a sampled out error not to be computed")
+		return ""
+	})
+	if s.Allow(err) || !d.Seen(err) {
+		t.Error("Expected the lazy error to be dropped")
+	}
+}
+
+func TestNewLazyConcurrent(t *testing.T) {
+	var calls atomic.Int32
+	err := NewLazy(SystemError, "sync_failed", func() string {
+		calls.Add(1)
+		return "Sync failed"
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = err.Error()
+		}()
+	}
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("Expected the message to be computed once, got %d calls", calls.Load())
+	}
+}
+
+func TestNewLazyCopies(t *testing.T) {
+	newErr := func() *ManagedError {
+		return NewLazy(SystemError, "sync_failed", func() string { return "Sync failed" })
+	}
+
+	data, err := json.Marshal(newErr())
+	if err != nil {
+		t.Fatalf("Failed to marshal error: %v", err)
+	}
+	if !strings.Contains(string(data), `"message":"Sync failed"`) {
+		t.Errorf("Expected the computed message in JSON, got %s", data)
+	}
+
+	if got := newErr().Sanitized().Message; got != "Sync failed" {
+		t.Errorf("Expected the sanitized copy to keep the computed message, got %q", got)
+	}
+
+	if got := Transform(newErr(), map[ErrorType]ErrorType{SystemError: ExternalError}).(*ManagedError).Message; got != "Sync failed" {
+		t.Errorf("Expected the transformed copy to keep the computed message, got %q", got)
+	}
+}
@@ -0,0 +1,46 @@
+package errmgt
+
+// LogFields flattens err into a map suitable for logging libraries that
+// accept key/value fields, such as logrus.WithFields or a loop of zap.Any.
+// Context entries are prefixed with "context." and the cause is flattened
+// recursively with a "cause." prefix. Errors that are not ManagedErrors
+// produce only a "message" field. LogFields returns nil for a nil error.
+func LogFields(err error) map[string]interface{} {
+	if err == nil {
+		return nil
+	}
+	fields := make(map[string]interface{})
+	addLogFields(fields, "", err)
+	return fields
+}
+
+func addLogFields(fields map[string]interface{}, prefix string, err error) {
+	managedErr, ok := asManaged(err)
+	if !ok {
+		fields[prefix+"message"] = err.Error()
+		return
+	}
+
+	fields[prefix+"type"] = string(managedErr.Type)
+	fields[prefix+"code"] = string(managedErr.EffectiveCode())
+	fields[prefix+"message"] = managedErr.GetMessage()
+	fields[prefix+"retryable"] = managedErr.Retryable
+	if managedErr.Details != "" {
+		fields[prefix+"details"] = managedErr.Details
+	}
+	if managedErr.Severity != 0 {
+		fields[prefix+"severity"] = managedErr.Severity.String()
+	}
+	if managedErr.StatusCode != 0 {
+		fields[prefix+"status_code"] = managedErr.StatusCode
+	}
+	if len(managedErr.Tags) > 0 {
+		fields[prefix+"tags"] = managedErr.Tags
+	}
+	for k, v := range managedErr.Context {
+		fields[prefix+"context."+k] = v
+	}
+	if managedErr.Cause != nil {
+		addLogFields(fields, prefix+"cause.", managedErr.Cause)
+	}
+}
@@ -0,0 +1,50 @@
+package errmgt
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestLogFields(t *testing.T) {
+	root := errors.New("connection refused")
+	inner := NewErrorWithCause(ExternalError, "api_unavailable", "API unavailable", root).
+		WithRetryable(true)
+	err := NewErrorWithCause(SystemError, "sync_failed", "Sync failed", inner).
+		WithDetails("nightly job").
+		WithStatusCode(500).
+		WithContext("operation", "sync_users").
+		WithContext("table", "users")
+
+	expected := map[string]interface{}{
+		"type":                "system",
+		"code":                "sync_failed",
+		"message":             "Sync failed",
+		"details":             "nightly job",
+		"retryable":           false,
+		"status_code":         500,
+		"context.operation":   "sync_users",
+		"context.table":       "users",
+		"cause.type":          "external",
+		"cause.code":          "api_unavailable",
+		"cause.message":       "API unavailable",
+		"cause.retryable":     true,
+		"cause.cause.message": "connection refused",
+	}
+
+	if fields := LogFields(err); !reflect.DeepEqual(fields, expected) {
+		t.Errorf("LogFields() = %v, want %v", fields, expected)
+	}
+}
+
+func TestLogFieldsNonManaged(t *testing.T) {
+	if fields := LogFields(nil); fields != nil {
+		t.Errorf("Expected nil fields for nil error, got %v", fields)
+	}
+
+	fields := LogFields(errors.New("plain error"))
+	expected := map[string]interface{}{"message": "plain error"}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("LogFields() = %v, want %v", fields, expected)
+	}
+}
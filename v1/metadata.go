@@ -0,0 +1,22 @@
+package errmgt
+
+// WithMetadata attaches an arbitrary JSON-serializable payload to the
+// error, such as the request body that caused it. Metadata is encoded under
+// "metadata" in JSON but never appears in Error(). When decoded from JSON
+// it holds the generic encoding/json representation of the payload.
+func (e *ManagedError) WithMetadata(metadata interface{}) *ManagedError {
+	if e == nil {
+		return nil
+	}
+	e.Metadata = metadata
+	return e
+}
+
+// GetMetadata retrieves the metadata attached to an error. The boolean is
+// false when err is not a ManagedError or carries no metadata.
+func GetMetadata(err error) (interface{}, bool) {
+	if managedErr, ok := asManaged(err); ok && managedErr.Metadata != nil {
+		return managedErr.Metadata, true
+	}
+	return nil, false
+}
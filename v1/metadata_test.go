@@ -0,0 +1,56 @@
+package errmgt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type signupRequest struct {
+	Email string `json:"email"`
+	Age   int    `json:"age"`
+}
+
+func TestManagedErrorWithMetadata(t *testing.T) {
+	payload := signupRequest{Email: "not-an-email", Age: 7}
+	err := NewError(ValidationError, "invalid_signup", "Invalid signup").WithMetadata(payload)
+
+	if strings.Contains(err.Error(), "not-an-email") {
+		t.Errorf("Expected metadata to be excluded from Error(), got %s", err.Error())
+	}
+
+	got, ok := GetMetadata(fmt.Errorf("wrapped: %w", err))
+	if !ok || got != payload {
+		t.Errorf("GetMetadata() = %v, %v, want %v, true", got, ok, payload)
+	}
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("Failed to marshal: %v", marshalErr)
+	}
+	if !strings.Contains(string(data), `"metadata":{"email":"not-an-email","age":7}`) {
+		t.Errorf("Expected metadata in JSON, got %s", data)
+	}
+
+	var decoded ManagedError
+	if unmarshalErr := json.Unmarshal(data, &decoded); unmarshalErr != nil {
+		t.Fatalf("Failed to unmarshal: %v", unmarshalErr)
+	}
+	expected := map[string]interface{}{"email": "not-an-email", "age": float64(7)}
+	if !reflect.DeepEqual(decoded.Metadata, expected) {
+		t.Errorf("Decoded metadata = %v, want %v", decoded.Metadata, expected)
+	}
+}
+
+func TestGetMetadataMissing(t *testing.T) {
+	if _, ok := GetMetadata(NewError(SystemError, "db_error", "Database error")); ok {
+		t.Error("Expected no metadata on a new error")
+	}
+
+	if _, ok := GetMetadata(errors.New("plain")); ok {
+		t.Error("Expected no metadata on a plain error")
+	}
+}
@@ -0,0 +1,116 @@
+package errmgt
+
+import (
+	"sort"
+	"strings"
+)
+
+// MultiError aggregates several errors into a single error
+type MultiError struct {
+	Errors []error
+}
+
+// Error implements the error interface. Errors are listed by priority,
+// highest first, as ordered by SortByPriority.
+func (m *MultiError) Error() string {
+	messages := make([]string, len(m.Errors))
+	for i, err := range SortByPriority(m) {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the aggregated errors so errors.Is and errors.As inspect
+// each of them
+func (m *MultiError) Unwrap() []error {
+	return m.Errors
+}
+
+// Append adds errs to err, returning a MultiError. If err is already a
+// MultiError the result contains its errors followed by errs. Nil errors
+// are dropped, and nil is returned when there is nothing to aggregate.
+func Append(err error, errs ...error) error {
+	var all []error
+	if multi, ok := err.(*MultiError); ok {
+		all = append(all, multi.Errors...)
+	} else if err != nil {
+		all = append(all, err)
+	}
+	for _, e := range errs {
+		if e != nil {
+			all = append(all, e)
+		}
+	}
+	if len(all) == 0 {
+		return nil
+	}
+	return &MultiError{Errors: all}
+}
+
+// Flatten collapses MultiErrors nested inside err into a single MultiError,
+// preserving order and dropping nil errors. It returns the sole remaining
+// error when only one is left and nil when none are. Errors that are not
+// MultiErrors are returned unchanged.
+func Flatten(err error) error {
+	multi, ok := err.(*MultiError)
+	if !ok {
+		return err
+	}
+	flat := flattenInto(nil, multi)
+	switch len(flat) {
+	case 0:
+		return nil
+	case 1:
+		return flat[0]
+	default:
+		return &MultiError{Errors: flat}
+	}
+}
+
+func flattenInto(flat []error, multi *MultiError) []error {
+	for _, err := range multi.Errors {
+		if nested, ok := err.(*MultiError); ok {
+			flat = flattenInto(flat, nested)
+		} else if err != nil {
+			flat = append(flat, err)
+		}
+	}
+	return flat
+}
+
+// WithPriority sets how prominently the error is shown when aggregated
+// with others. Higher priorities come first; the default is 0.
+func (e *ManagedError) WithPriority(priority int) *ManagedError {
+	if e == nil {
+		return nil
+	}
+	e.Priority = priority
+	return e
+}
+
+// SortByPriority returns the errors aggregated in err ordered by priority,
+// highest first, keeping insertion order among equal priorities. Errors
+// that are not ManagedErrors have priority 0. A non-multi error is
+// returned as a one-element slice and nil as nil. err is not modified.
+func SortByPriority(err error) []error {
+	if err == nil {
+		return nil
+	}
+	multi, ok := err.(*MultiError)
+	if !ok {
+		return []error{err}
+	}
+	sorted := append([]error(nil), multi.Errors...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return priority(sorted[i]) > priority(sorted[j])
+	})
+	return sorted
+}
+
+// priority returns the priority of the first ManagedError in err's chain
+func priority(err error) int {
+	if managedErr, ok := asManaged(err); ok {
+		return managedErr.Priority
+	}
+	return 0
+}
@@ -0,0 +1,142 @@
+package errmgt
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAppend(t *testing.T) {
+	err1 := errors.New("first")
+	err2 := NewError(ValidationError, "invalid_email", "Invalid email")
+
+	if err := Append(nil); err != nil {
+		t.Errorf("Expected nil when appending nothing, got %v", err)
+	}
+
+	if err := Append(nil, nil, nil); err != nil {
+		t.Errorf("Expected nil when appending only nils, got %v", err)
+	}
+
+	err := Append(nil, err1)
+	err = Append(err, nil, err2)
+
+	var multi *MultiError
+	if !errors.As(err, &multi) {
+		t.Fatal("Expected a MultiError")
+	}
+
+	if len(multi.Errors) != 2 {
+		t.Fatalf("Expected 2 errors, got %d", len(multi.Errors))
+	}
+
+	if multi.Errors[0] != err1 || multi.Errors[1] != err2 {
+		t.Error("Expected errors to be kept in order")
+	}
+
+	expected := "first; [validation:invalid_email] Invalid email"
+	if err.Error() != expected {
+		t.Errorf("Expected message '%s', got '%s'", expected, err.Error())
+	}
+}
+
+func TestAppendDoesNotModifyOriginal(t *testing.T) {
+	original := Append(nil, errors.New("first"))
+	_ = Append(original, errors.New("second"))
+
+	if n := len(original.(*MultiError).Errors); n != 1 {
+		t.Errorf("Expected original MultiError to keep 1 error, got %d", n)
+	}
+}
+
+func TestMultiErrorUnwrap(t *testing.T) {
+	cause := errors.New("cause")
+	err := Append(errors.New("first"), NewErrorWithCause(SystemError, "db_error", "Database error", cause))
+
+	if !errors.Is(err, cause) {
+		t.Error("Expected errors.Is to find the cause inside the MultiError")
+	}
+
+	if !IsType(err, SystemError) {
+		t.Error("Expected IsType to find the ManagedError inside the MultiError")
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	err1 := errors.New("first")
+	err2 := errors.New("second")
+	err3 := errors.New("third")
+
+	nested := Append(err1, Append(err2, &MultiError{Errors: []error{nil, Append(nil, err3)}}))
+
+	flat, ok := Flatten(nested).(*MultiError)
+	if !ok {
+		t.Fatal("Expected a MultiError")
+	}
+
+	expected := []error{err1, err2, err3}
+	if len(flat.Errors) != len(expected) {
+		t.Fatalf("Expected %d errors, got %d", len(expected), len(flat.Errors))
+	}
+	for i := range expected {
+		if flat.Errors[i] != expected[i] {
+			t.Errorf("Expected error %d to be %v, got %v", i, expected[i], flat.Errors[i])
+		}
+	}
+}
+
+func TestFlattenCollapses(t *testing.T) {
+	err := errors.New("only")
+
+	if got := Flatten(&MultiError{Errors: []error{&MultiError{Errors: []error{err}}}}); got != err {
+		t.Errorf("Expected single error to be returned, got %v", got)
+	}
+
+	if got := Flatten(&MultiError{Errors: []error{nil, &MultiError{}}}); got != nil {
+		t.Errorf("Expected nil for an empty MultiError, got %v", got)
+	}
+
+	if got := Flatten(err); got != err {
+		t.Errorf("Expected non-MultiError to be returned unchanged, got %v", got)
+	}
+
+	if got := Flatten(nil); got != nil {
+		t.Errorf("Expected nil, got %v", got)
+	}
+}
+
+func TestSortByPriority(t *testing.T) {
+	cosmetic1 := NewError(ValidationError, "trailing_space", "Trailing space")
+	cosmetic2 := NewError(ValidationError, "mixed_case", "Mixed case")
+	plain := errors.New("plain")
+	blocking := NewError(BusinessError, "permission_denied", "Permission denied").WithPriority(10)
+	warning := NewError(ValidationError, "deprecated_field", "Deprecated field").WithPriority(-1)
+	err := Append(warning, cosmetic1, plain, blocking, cosmetic2)
+
+	sorted := SortByPriority(err)
+	expected := []error{blocking, cosmetic1, plain, cosmetic2, warning}
+	if len(sorted) != len(expected) {
+		t.Fatalf("Expected %d errors, got %d", len(expected), len(sorted))
+	}
+	for i := range expected {
+		if sorted[i] != expected[i] {
+			t.Errorf("SortByPriority()[%d] = %v, want %v", i, sorted[i], expected[i])
+		}
+	}
+
+	if multi := err.(*MultiError); multi.Errors[0] != warning {
+		t.Error("Expected the MultiError not to be reordered")
+	}
+
+	if !strings.HasPrefix(err.Error(), "[business:permission_denied] Permission denied; ") {
+		t.Errorf("Expected the highest priority error first, got %s", err.Error())
+	}
+
+	if single := SortByPriority(plain); len(single) != 1 || single[0] != plain {
+		t.Errorf("Expected a one-element slice, got %v", single)
+	}
+
+	if SortByPriority(nil) != nil {
+		t.Error("Expected nil for nil")
+	}
+}
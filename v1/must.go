@@ -0,0 +1,13 @@
+package errmgt
+
+// Must returns err, panicking if e is not nil. It is intended for
+// initializing package-level errors from constructors that validate their
+// input, so misconfiguration fails loudly at startup:
+//
+//	var ErrX = errmgt.Must(newValidatedError(...))
+func Must(err *ManagedError, e error) *ManagedError {
+	if e != nil {
+		panic(e)
+	}
+	return err
+}
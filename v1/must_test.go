@@ -0,0 +1,27 @@
+package errmgt
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMust(t *testing.T) {
+	err := NewError(ValidationError, "invalid_input", "Invalid input")
+
+	if got := Must(err, nil); got != err {
+		t.Errorf("Must() = %v, want %v", got, err)
+	}
+}
+
+func TestMustPanics(t *testing.T) {
+	failure := errors.New("invalid definition")
+
+	defer func() {
+		if r := recover(); r != failure {
+			t.Errorf("Expected Must to panic with %v, got %v", failure, r)
+		}
+	}()
+
+	Must(nil, failure)
+	t.Error("Expected Must to panic")
+}
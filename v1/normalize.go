@@ -0,0 +1,74 @@
+package errmgt
+
+import (
+	"regexp"
+	"sync/atomic"
+)
+
+// NormalizePlaceholder replaces each match of a normalization pattern
+const NormalizePlaceholder = "*"
+
+// DefaultNormalizePatterns match UUIDs and runs of digits, the dynamic
+// values most often embedded in error messages
+var DefaultNormalizePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`),
+	regexp.MustCompile(`[0-9]+`),
+}
+
+var normalizePatterns atomic.Pointer[[]*regexp.Regexp]
+
+// SetNormalizePatterns replaces the patterns Normalize strips from
+// messages. They are applied in order, each match being replaced with
+// NormalizePlaceholder. Passing no patterns restores
+// DefaultNormalizePatterns.
+func SetNormalizePatterns(patterns ...*regexp.Regexp) {
+	if len(patterns) == 0 {
+		normalizePatterns.Store(nil)
+		return
+	}
+	patterns = append([]*regexp.Regexp(nil), patterns...)
+	normalizePatterns.Store(&patterns)
+}
+
+// Normalize returns a canonical signature for err, so errors that differ
+// only by embedded identifiers group together. A ManagedError with a code
+// is identified by its type and code, e.g. "business:user_not_found".
+// Otherwise the message is used with every match of the normalization
+// patterns replaced, turning "user 123 not found" into "user * not
+// found". It returns an empty string for nil. Deduplicator, Sampler and
+// GetFingerprint group errors by Normalize.
+func Normalize(err error) string {
+	if err == nil {
+		return ""
+	}
+	if managedErr, ok := asManaged(err); ok {
+		if code := managedErr.groupingCode(); code != "" {
+			return string(managedErr.Type) + ":" + string(code)
+		}
+		return normalizeMessage(managedErr.GetMessage())
+	}
+	return normalizeMessage(err.Error())
+}
+
+// groupingCode returns the code the error is grouped by: its Code, or
+// with SetAutoCodeFromMessage a code derived from the normalized message,
+// so errors differing only in embedded identifiers share it
+func (e *ManagedError) groupingCode() Code {
+	if e.Code != "" || !autoCodeFromMessage.Load() {
+		return e.Code
+	}
+	return autoCode(normalizeMessage(e.GetMessage()))
+}
+
+// normalizeMessage replaces every match of the normalization patterns in
+// message with NormalizePlaceholder
+func normalizeMessage(message string) string {
+	patterns := DefaultNormalizePatterns
+	if custom := normalizePatterns.Load(); custom != nil {
+		patterns = *custom
+	}
+	for _, pattern := range patterns {
+		message = pattern.ReplaceAllString(message, NormalizePlaceholder)
+	}
+	return message
+}
@@ -0,0 +1,55 @@
+package errmgt
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{errors.New("user 123 not found"), "user * not found"},
+		{errors.New("order 3f2b8c1e-9d4a-4b6e-8f0a-1c2d3e4f5a6b failed"), "order * failed"},
+		{NewError(BusinessError, "", "user 456 not found"), "user * not found"},
+		{fmt.Errorf("lookup: %w", NewError(BusinessError, "user_not_found", "user 456 not found")), "business:user_not_found"},
+		{nil, ""},
+	}
+
+	for _, tt := range tests {
+		if got := Normalize(tt.err); got != tt.expected {
+			t.Errorf("Normalize(%v) = %q, want %q", tt.err, got, tt.expected)
+		}
+	}
+}
+
+func TestSetNormalizePatterns(t *testing.T) {
+	SetNormalizePatterns(regexp.MustCompile(`req-[a-z0-9]+`))
+	defer SetNormalizePatterns()
+
+	if got := Normalize(errors.New("request req-x7k2 failed after 3 tries")); got != "request * failed after 3 tries" {
+		t.Errorf("Expected custom patterns to apply, got %q", got)
+	}
+
+	SetNormalizePatterns()
+	if got := Normalize(errors.New("failed after 3 tries")); got != "failed after * tries" {
+		t.Errorf("Expected default patterns to be restored, got %q", got)
+	}
+}
+
+func TestNormalizeGrouping(t *testing.T) {
+	d := NewDeduplicator(time.Minute)
+	if d.Seen(errors.New("user 123 not found")) || !d.Seen(errors.New("user 456 not found")) {
+		t.Error("Expected messages differing by identifiers to be deduplicated")
+	}
+
+	first := GetFingerprint(errors.New("user 123 not found"))
+	second := GetFingerprint(errors.New("user 456 not found"))
+	if len(first) != 1 || first[0] != second[0] {
+		t.Errorf("Expected equal fingerprints, got %v and %v", first, second)
+	}
+}
@@ -0,0 +1,27 @@
+package errmgt
+
+import "time"
+
+// Observe records when handling code first dealt with the error, such as
+// the handler that logs it or turns it into a response. The first call
+// stamps ObservedAt and reports the error with ObserveDependency; later
+// calls do nothing, so it is safe to call at every layer.
+func (e *ManagedError) Observe() *ManagedError {
+	if e == nil {
+		return nil
+	}
+	if e.ObservedAt.IsZero() {
+		e.ObservedAt = currentTime().UTC()
+		ObserveDependency(e)
+	}
+	return e
+}
+
+// PropagationTime returns how long the error travelled between its
+// creation and its first observation, or zero if either time is unset
+func (e *ManagedError) PropagationTime() time.Duration {
+	if e == nil || e.Timestamp.IsZero() || e.ObservedAt.IsZero() {
+		return 0
+	}
+	return e.ObservedAt.Sub(e.Timestamp)
+}
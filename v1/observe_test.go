@@ -0,0 +1,72 @@
+package errmgt
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimestamp(t *testing.T) {
+	before := time.Now()
+	err := NewError(SystemError, "db_error", "Database error")
+
+	if err.Timestamp.Before(before.Truncate(time.Microsecond)) || err.Timestamp.After(time.Now()) {
+		t.Errorf("Expected the creation time, got %v", err.Timestamp)
+	}
+
+	if err.Timestamp.Location() != time.UTC {
+		t.Errorf("Expected a UTC timestamp, got %v", err.Timestamp.Location())
+	}
+}
+
+func TestObserve(t *testing.T) {
+	err := NewError(SystemError, "db_error", "Database error")
+	err.Timestamp = time.Now().Add(-time.Second).UTC()
+
+	if err.PropagationTime() != 0 {
+		t.Errorf("Expected no propagation time before Observe, got %v", err.PropagationTime())
+	}
+
+	observedAt := err.Observe().ObservedAt
+	if observedAt.IsZero() {
+		t.Fatal("Expected Observe to stamp ObservedAt")
+	}
+
+	if err.Observe().ObservedAt != observedAt {
+		t.Errorf("Expected Observe to keep the first observation, got %v", err.ObservedAt)
+	}
+
+	if d := err.PropagationTime(); d < time.Second {
+		t.Errorf("Expected a propagation time of at least a second, got %v", d)
+	}
+}
+
+func TestObserveJSON(t *testing.T) {
+	err := NewError(SystemError, "db_error", "Database error")
+	err.Timestamp = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	err.ObservedAt = time.Date(2024, 3, 1, 12, 0, 0, 250000000, time.UTC)
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("Failed to marshal error: %v", marshalErr)
+	}
+
+	for _, want := range []string{
+		`"timestamp":"2024-03-01T12:00:00Z"`,
+		`"observed_at":"2024-03-01T12:00:00.25Z"`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected JSON to contain %s, got %s", want, data)
+		}
+	}
+
+	var decoded ManagedError
+	if unmarshalErr := json.Unmarshal(data, &decoded); unmarshalErr != nil {
+		t.Fatalf("Failed to unmarshal error: %v", unmarshalErr)
+	}
+
+	if decoded.PropagationTime() != 250*time.Millisecond {
+		t.Errorf("Expected a propagation time of 250ms after round trip, got %v", decoded.PropagationTime())
+	}
+}
@@ -0,0 +1,26 @@
+package errmgt
+
+// WithOccurrences records how many occurrences the error stands for, for
+// example when it represents a batch of duplicates suppressed by a
+// Deduplicator
+func (e *ManagedError) WithOccurrences(n int) *ManagedError {
+	if e == nil {
+		return nil
+	}
+	e.Occurrences = n
+	return e
+}
+
+// IncrementOccurrences adds one to the error's occurrence count. An error
+// whose count was never set is treated as a single occurrence, so the
+// first increment brings the count to 2.
+func (e *ManagedError) IncrementOccurrences() *ManagedError {
+	if e == nil {
+		return nil
+	}
+	if e.Occurrences == 0 {
+		e.Occurrences = 1
+	}
+	e.Occurrences++
+	return e
+}
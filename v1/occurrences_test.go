@@ -0,0 +1,46 @@
+package errmgt
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestManagedErrorOccurrences(t *testing.T) {
+	err := NewError(ExternalError, "api_timeout", "API timeout")
+
+	if err.Occurrences != 0 {
+		t.Errorf("Expected no occurrence count by default, got %d", err.Occurrences)
+	}
+
+	if err.IncrementOccurrences().Occurrences != 2 {
+		t.Errorf("Expected first increment to count 2 occurrences, got %d", err.Occurrences)
+	}
+
+	if err.WithOccurrences(46).IncrementOccurrences().Occurrences != 47 {
+		t.Errorf("Expected 47 occurrences, got %d", err.Occurrences)
+	}
+
+	if got := fmt.Sprintf("%+v", err); !strings.Contains(got, "\n    occurrences: 47") {
+		t.Errorf("Expected occurrences in verbose output, got\n%s", got)
+	}
+}
+
+func TestManagedErrorOccurrencesWithDeduplicator(t *testing.T) {
+	dedup := NewDeduplicator(time.Minute)
+
+	var representative *ManagedError
+	for i := 0; i < 5; i++ {
+		err := NewError(ExternalError, "api_timeout", "API timeout")
+		if !dedup.Seen(err) {
+			representative = err.WithOccurrences(1)
+			continue
+		}
+		representative.IncrementOccurrences()
+	}
+
+	if representative.Occurrences != 5 {
+		t.Errorf("Expected representative to count 5 occurrences, got %d", representative.Occurrences)
+	}
+}
@@ -0,0 +1,74 @@
+package errmgt
+
+import "time"
+
+// Option configures an error created with NewWithOptions
+type Option func(*ManagedError)
+
+// NewWithOptions creates a new ManagedError and applies opts to it in
+// order. It suits errors assembled conditionally, where a slice of
+// options is easier to build up than a method chain:
+//
+//	opts := []errmgt.Option{errmgt.Context("user_id", id)}
+//	if temporary {
+//		opts = append(opts, errmgt.WithRetryable(true))
+//	}
+//	return errmgt.NewWithOptions(errmgt.ExternalError, "api_timeout", "API timeout", opts...)
+func NewWithOptions(errType ErrorType, code Code, message string, opts ...Option) *ManagedError {
+	e := newError(1, errType, code, message, nil)
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// WithStatusCode returns an Option that sets the HTTP status code
+func WithStatusCode(code int) Option {
+	return func(e *ManagedError) {
+		e.WithStatusCode(code)
+	}
+}
+
+// WithRetryable returns an Option that sets whether the error is retryable
+func WithRetryable(retryable bool) Option {
+	return func(e *ManagedError) {
+		e.WithRetryable(retryable)
+	}
+}
+
+// WithRetryAfter returns an Option that sets how long callers should wait
+// before retrying
+func WithRetryAfter(d time.Duration) Option {
+	return func(e *ManagedError) {
+		e.WithRetryAfter(d)
+	}
+}
+
+// WithDetails returns an Option that sets the error's details
+func WithDetails(details string) Option {
+	return func(e *ManagedError) {
+		e.WithDetails(details)
+	}
+}
+
+// WithUserMessage returns an Option that sets the message returned by
+// UserError
+func WithUserMessage(message string) Option {
+	return func(e *ManagedError) {
+		e.WithUserMessage(message)
+	}
+}
+
+// Context returns an Option that adds a context entry
+func Context(key, value string) Option {
+	return func(e *ManagedError) {
+		e.WithContext(key, value)
+	}
+}
+
+// Cause returns an Option that sets the error's cause
+func Cause(err error) Option {
+	return func(e *ManagedError) {
+		e.Cause = err
+	}
+}
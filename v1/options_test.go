@@ -0,0 +1,68 @@
+package errmgt
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := NewWithOptions(ExternalError, "api_timeout", "API timeout",
+		WithStatusCode(504),
+		WithRetryable(true),
+		WithRetryAfter(time.Second),
+		WithDetails("upstream did not respond"),
+		WithUserMessage("Try again later"),
+		Context("endpoint", "/users"),
+		Cause(cause),
+	)
+
+	if err.Type != ExternalError || err.Code != "api_timeout" || err.Message != "API timeout" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	if err.GetID() == "" {
+		t.Error("Expected an ID to be generated")
+	}
+
+	if err.StatusCode != 504 || !err.Retryable || err.RetryAfter != time.Second {
+		t.Errorf("Expected status, retryable and retry after to be set, got %+v", err)
+	}
+
+	if err.Details != "upstream did not respond" || err.UserMessage != "Try again later" {
+		t.Errorf("Expected details and user message to be set, got %+v", err)
+	}
+
+	if err.Context["endpoint"] != "/users" {
+		t.Errorf("Expected context to be set, got %v", err.Context)
+	}
+
+	if !errors.Is(err, cause) {
+		t.Error("Expected the cause to be set")
+	}
+}
+
+func TestNewWithOptionsConditional(t *testing.T) {
+	var opts []Option
+	if temporary := false; temporary {
+		opts = append(opts, WithRetryable(true))
+	}
+
+	err := NewWithOptions(SystemError, "db_error", "Database error", opts...)
+	if err.Retryable {
+		t.Error("Expected unapplied options to leave the error unchanged")
+	}
+}
+
+func TestNewWithOptionsStack(t *testing.T) {
+	SetCaptureStack(true)
+	defer SetCaptureStack(false)
+
+	err := NewWithOptions(SystemError, "db_error", "Database error")
+	frames := err.StackTrace()
+	if len(frames) == 0 || !strings.HasSuffix(frames[0].Function, "TestNewWithOptionsStack") {
+		t.Errorf("Expected the stack to start at the caller, got %v", frames)
+	}
+}
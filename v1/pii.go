@@ -0,0 +1,49 @@
+package errmgt
+
+// PIIPlaceholder replaces the message of errors scrubbed by
+// ScrubForLogging
+const PIIPlaceholder = "[redacted: contains PII]"
+
+// MarkPII marks the error as containing personally identifiable
+// information in its message, details or context, so ScrubForLogging
+// removes them before the error is logged
+func (e *ManagedError) MarkPII() *ManagedError {
+	if e == nil {
+		return nil
+	}
+	e.ContainsPII = true
+	return e
+}
+
+// HasPII reports whether any ManagedError in err's chain was marked with
+// MarkPII
+func HasPII(err error) bool {
+	found := false
+	walkChain(err, func(e error) {
+		if managedErr, ok := e.(*ManagedError); ok && managedErr != nil && managedErr.ContainsPII {
+			found = true
+		}
+	})
+	return found
+}
+
+// ScrubForLogging returns err as a ManagedError that is safe to log. When
+// HasPII reports PII anywhere in the chain, the result is a new error
+// carrying only the ID, Type, Code and StatusCode of the error Ensure
+// returns, with PIIPlaceholder as its message and no details, context or
+// cause. Otherwise the error Ensure returns is passed through unchanged.
+// It returns nil for nil.
+func ScrubForLogging(err error) *ManagedError {
+	managedErr := Ensure(err)
+	if managedErr == nil || !HasPII(err) {
+		return managedErr
+	}
+	return &ManagedError{
+		ID:          managedErr.GetID(),
+		Type:        managedErr.Type,
+		Code:        managedErr.Code,
+		Message:     PIIPlaceholder,
+		StatusCode:  managedErr.StatusCode,
+		ContainsPII: true,
+	}
+}
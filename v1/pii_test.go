@@ -0,0 +1,64 @@
+package errmgt
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestMarkPII(t *testing.T) {
+	err := NewError(ValidationError, "invalid_email", "Invalid email alice@example.com").MarkPII()
+
+	if !err.ContainsPII {
+		t.Error("Expected MarkPII to set ContainsPII")
+	}
+
+	if !HasPII(fmt.Errorf("signup: %w", err)) {
+		t.Error("Expected HasPII to find the marked error through wrapping")
+	}
+
+	if HasPII(NewError(ValidationError, "invalid_email", "Invalid email")) || HasPII(errors.New("plain")) || HasPII(nil) {
+		t.Error("Expected HasPII to be false for unmarked errors")
+	}
+}
+
+func TestScrubForLogging(t *testing.T) {
+	cause := NewError(BusinessError, "user_blocked", "User alice@example.com is blocked").MarkPII()
+	err := NewErrorWithCause(ValidationError, "invalid_email", "Invalid email", cause).
+		WithDetails("alice@example.com").
+		WithContext("email", "alice@example.com").
+		WithStatusCode(400)
+
+	scrubbed := ScrubForLogging(err)
+
+	if scrubbed == err {
+		t.Fatal("Expected a scrubbed copy")
+	}
+
+	if scrubbed.GetID() != err.GetID() || scrubbed.Type != ValidationError || scrubbed.Code != "invalid_email" || scrubbed.StatusCode != 400 {
+		t.Errorf("Expected the ID, type, code and status to be kept, got %+v", scrubbed)
+	}
+
+	if scrubbed.Message != PIIPlaceholder || scrubbed.Details != "" || scrubbed.Context != nil || scrubbed.Cause != nil {
+		t.Errorf("Expected the message, details, context and cause to be removed, got %+v", scrubbed)
+	}
+
+	if err.Message != "Invalid email" || err.Details != "alice@example.com" {
+		t.Error("Expected the original error to be unchanged")
+	}
+}
+
+func TestScrubForLoggingPassthrough(t *testing.T) {
+	err := NewError(SystemError, "db_error", "Database error")
+	if scrubbed := ScrubForLogging(fmt.Errorf("query: %w", err)); scrubbed != err {
+		t.Errorf("Expected non-PII errors to pass through, got %v", scrubbed)
+	}
+
+	if scrubbed := ScrubForLogging(errors.New("plain")); scrubbed == nil || scrubbed.Code != InternalErrorCode {
+		t.Errorf("Expected plain errors to be converted with Ensure, got %v", scrubbed)
+	}
+
+	if ScrubForLogging(nil) != nil {
+		t.Error("Expected nil for nil")
+	}
+}
@@ -0,0 +1,35 @@
+package errmgt
+
+import "sync"
+
+var errorPool = sync.Pool{
+	New: func() interface{} {
+		return new(ManagedError)
+	},
+}
+
+// GetError returns an empty ManagedError from a pool, for hot paths where
+// allocating an error per failure shows up in GC profiles. Unlike
+// NewError it assigns no ID, timestamp, stack, caller or global context;
+// set the fields directly or with the With methods. Its Context may be an
+// empty, non-nil map kept from an earlier use.
+//
+// Pooled errors must be returned with PutError once the caller is done
+// with them, and must not be used or retained afterwards, including
+// through wrapping errors, loggers or channels. Most code should use the
+// allocating constructors instead.
+func GetError() *ManagedError {
+	return errorPool.Get().(*ManagedError)
+}
+
+// PutError resets e and returns it to the pool used by GetError. e must
+// not be used after PutError returns. PutError ignores nil.
+func PutError(e *ManagedError) {
+	if e == nil {
+		return
+	}
+	context := e.Context
+	clear(context)
+	*e = ManagedError{Context: context}
+	errorPool.Put(e)
+}
@@ -0,0 +1,49 @@
+package errmgt
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGetErrorPutError(t *testing.T) {
+	pinVerbosity(t, Terse)
+
+	e := GetError()
+	e.Type = ValidationError
+	e.Code = "invalid_input"
+	e.Message = "Input validation failed"
+	e.WithContext("field", "email").WithRetryable(true).WithTags("form")
+	e.Cause = errors.New("cause")
+
+	if e.Error() != "[validation:invalid_input] Input validation failed" {
+		t.Errorf("Unexpected Error() output: %s", e.Error())
+	}
+
+	PutError(e)
+
+	if e.Type != "" || e.Code != "" || e.Message != "" || e.Retryable || e.Cause != nil || e.Tags != nil {
+		t.Errorf("Expected PutError to reset the error, got %+v", e)
+	}
+
+	if len(e.Context) != 0 {
+		t.Errorf("Expected the context to be cleared, got %v", e.Context)
+	}
+
+	if reused := GetError(); reused.Code != "" || len(reused.Context) != 0 {
+		t.Errorf("Expected a clean error from the pool, got %+v", reused)
+	}
+
+	PutError(nil)
+}
+
+func BenchmarkPooledErrorWithContext(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		e := GetError()
+		e.Type = ValidationError
+		e.Code = "invalid_input"
+		e.Message = "Input validation failed"
+		e.WithContext("field", "email")
+		PutError(e)
+	}
+}
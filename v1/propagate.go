@@ -0,0 +1,50 @@
+package errmgt
+
+import "sync/atomic"
+
+var propagatableContextKeys atomic.Pointer[map[string]bool]
+
+// SetPropagatableContextKeys restricts the context keys MarshalJSON
+// encodes, and so the context that travels to other services, to keys.
+// Other keys stay on the error locally. Calling it without keys removes
+// the restriction, which is the default. Errors marked with
+// IncludeAllContext are always encoded with their whole context.
+func SetPropagatableContextKeys(keys ...string) {
+	if len(keys) == 0 {
+		propagatableContextKeys.Store(nil)
+		return
+	}
+	allowed := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		allowed[key] = true
+	}
+	propagatableContextKeys.Store(&allowed)
+}
+
+// IncludeAllContext makes MarshalJSON encode the error's whole context
+// even when SetPropagatableContextKeys restricts it
+func (e *ManagedError) IncludeAllContext() *ManagedError {
+	if e == nil {
+		return nil
+	}
+	e.includeAllContext = true
+	return e
+}
+
+// propagatedContext returns the context entries MarshalJSON encodes
+func (e *ManagedError) propagatedContext() map[string]string {
+	allowed := propagatableContextKeys.Load()
+	if allowed == nil || e.includeAllContext {
+		return e.Context
+	}
+	var context map[string]string
+	for key, value := range e.Context {
+		if (*allowed)[key] {
+			if context == nil {
+				context = make(map[string]string)
+			}
+			context[key] = value
+		}
+	}
+	return context
+}
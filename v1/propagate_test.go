@@ -0,0 +1,58 @@
+package errmgt
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSetPropagatableContextKeys(t *testing.T) {
+	newErr := func() *ManagedError {
+		return NewError(SystemError, "db_error", "Database error").
+			WithContext("tenant", "acme").
+			WithContext("query", "SELECT * FROM users").
+			WithContext("region", "eu-1")
+	}
+
+	SetPropagatableContextKeys("tenant", "region")
+	defer SetPropagatableContextKeys()
+
+	err := newErr()
+	decoded := roundTripJSON(t, err)
+
+	if want := map[string]string{"tenant": "acme", "region": "eu-1"}; !reflect.DeepEqual(decoded.Context, want) {
+		t.Errorf("Expected only propagatable keys to be encoded, got %v", decoded.Context)
+	}
+
+	if len(err.Context) != 3 {
+		t.Errorf("Expected the local context to be unchanged, got %v", err.Context)
+	}
+
+	if decoded := roundTripJSON(t, newErr().IncludeAllContext()); len(decoded.Context) != 3 {
+		t.Errorf("Expected IncludeAllContext to encode every key, got %v", decoded.Context)
+	}
+
+	SetPropagatableContextKeys()
+	if decoded := roundTripJSON(t, newErr()); len(decoded.Context) != 3 {
+		t.Errorf("Expected every key without a restriction, got %v", decoded.Context)
+	}
+}
+
+func TestSetPropagatableContextKeysNoMatch(t *testing.T) {
+	SetPropagatableContextKeys("tenant")
+	defer SetPropagatableContextKeys()
+
+	data, err := json.Marshal(NewError(SystemError, "db_error", "Database error").WithContext("query", "SELECT 1"))
+	if err != nil {
+		t.Fatalf("Failed to marshal error: %v", err)
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Failed to unmarshal error: %v", err)
+	}
+
+	if _, exists := fields["context"]; exists {
+		t.Errorf("Expected no context without propagatable keys, got %s", data)
+	}
+}
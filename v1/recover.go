@@ -0,0 +1,80 @@
+package errmgt
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// PanicCode is the code of errors created from recovered panics
+const PanicCode Code = "panic"
+
+// Recover converts a panic into a ManagedError stored in *errp. It must be
+// deferred directly:
+//
+//	func handle() (err error) {
+//		defer errmgt.Recover(&err)
+//		...
+//	}
+//
+// Each classifier is offered the recovered value in turn and may return a
+// ManagedError describing it; the first non-nil result is used. It is
+// copied before the stack trace is attached, so classifiers may return
+// shared sentinel errors. When no classifier matches, a SystemError with
+// code PanicCode is created, with the recovered value as its Cause if it
+// is an error. The resulting error always carries a stack trace whose top
+// frame is where the panic occurred, regardless of SetCaptureStack.
+// Recover does nothing when there is no panic.
+func Recover(errp *error, classifiers ...func(recovered interface{}) *ManagedError) {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+
+	var err *ManagedError
+	for _, classify := range classifiers {
+		if classified := classify(recovered); classified != nil {
+			err = classified.clone()
+			break
+		}
+	}
+	if err == nil {
+		cause, _ := recovered.(error)
+		err = NewErrorWithCause(SystemError, PanicCode, fmt.Sprintf("panic: %v", recovered), cause).
+			WithSeverity(SeverityCritical)
+	}
+	err.stack = panicCallers()
+	*errp = err
+}
+
+// panicCallers returns the stack of the panicking goroutine starting at the
+// function that panicked. It must be called from a deferred function while
+// the goroutine is panicking.
+func panicCallers() []uintptr {
+	// Leave room for the runtime and deferred frames above the panic
+	pcs := make([]uintptr, stackDepth()+96)
+	n := runtime.Callers(1, pcs)
+	pcs = pcs[:n]
+
+	start := 0
+	for i, pc := range pcs {
+		if fn := runtime.FuncForPC(pc - 1); fn != nil && fn.Name() == "runtime.gopanic" {
+			start = i + 1
+			break
+		}
+	}
+	// Runtime errors such as index out of range panic from runtime helpers
+	for start < len(pcs) {
+		fn := runtime.FuncForPC(pcs[start] - 1)
+		if fn == nil || !strings.HasPrefix(fn.Name(), "runtime.") {
+			break
+		}
+		start++
+	}
+
+	pcs = pcs[start:]
+	if depth := stackDepth(); len(pcs) > depth {
+		pcs = pcs[:depth]
+	}
+	return pcs
+}
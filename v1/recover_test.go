@@ -0,0 +1,143 @@
+package errmgt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type outOfRange struct {
+	index int
+}
+
+func panicLevelOne(value interface{}) {
+	panicLevelTwo(value)
+}
+
+func panicLevelTwo(value interface{}) {
+	panicLevelThree(value)
+}
+
+func panicLevelThree(value interface{}) {
+	panic(value)
+}
+
+func recoverPanic(value interface{}, classifiers ...func(interface{}) *ManagedError) (err error) {
+	defer Recover(&err, classifiers...)
+	panicLevelOne(value)
+	return nil
+}
+
+func TestRecover(t *testing.T) {
+	err := recoverPanic("something broke")
+
+	var managedErr *ManagedError
+	if !errors.As(err, &managedErr) {
+		t.Fatalf("Expected a ManagedError, got %v", err)
+	}
+
+	if managedErr.Type != SystemError || managedErr.Code != PanicCode {
+		t.Errorf("Expected system panic error, got %v", managedErr)
+	}
+
+	if managedErr.Message != "panic: something broke" {
+		t.Errorf("Expected message 'panic: something broke', got '%s'", managedErr.Message)
+	}
+
+	if managedErr.Severity != SeverityCritical {
+		t.Errorf("Expected critical severity, got %s", managedErr.Severity)
+	}
+}
+
+func TestRecoverErrorValue(t *testing.T) {
+	cause := errors.New("boom")
+	err := recoverPanic(cause)
+
+	if !errors.Is(err, cause) {
+		t.Error("Expected panic error value to be the cause")
+	}
+}
+
+func TestRecoverNoPanic(t *testing.T) {
+	err := func() (err error) {
+		defer Recover(&err)
+		return nil
+	}()
+
+	if err != nil {
+		t.Errorf("Expected nil without a panic, got %v", err)
+	}
+}
+
+func TestRecoverClassifier(t *testing.T) {
+	classifyOutOfRange := func(recovered interface{}) *ManagedError {
+		if r, ok := recovered.(outOfRange); ok {
+			return NewError(ValidationError, "out_of_range", fmt.Sprintf("index %d out of range", r.index))
+		}
+		return nil
+	}
+	never := func(interface{}) *ManagedError { return nil }
+
+	err := recoverPanic(outOfRange{index: 7}, never, classifyOutOfRange)
+	if !IsType(err, ValidationError) {
+		t.Errorf("Expected classified validation error, got %v", err)
+	}
+	if err.(*ManagedError).Message != "index 7 out of range" {
+		t.Errorf("Expected classified message, got '%s'", err.(*ManagedError).Message)
+	}
+
+	err = recoverPanic("unclassified", classifyOutOfRange)
+	if !IsType(err, SystemError) {
+		t.Errorf("Expected default system error, got %v", err)
+	}
+}
+
+func TestRecoverClassifierSentinel(t *testing.T) {
+	sentinel := NewError(ValidationError, "out_of_range", "Index out of range")
+	classify := func(interface{}) *ManagedError { return sentinel }
+
+	err := recoverPanic(outOfRange{index: 7}, classify)
+
+	if err == sentinel || !errors.Is(err, sentinel) {
+		t.Errorf("Expected a copy of the sentinel, got %v", err)
+	}
+
+	if err.(*ManagedError).StackTrace() == nil {
+		t.Error("Expected the copy to carry the panic's stack trace")
+	}
+
+	if sentinel.StackTrace() != nil {
+		t.Error("Expected the sentinel not to be modified")
+	}
+}
+
+func TestRecoverStackTrace(t *testing.T) {
+	err := recoverPanic("deep panic").(*ManagedError)
+
+	trace := err.StackTrace()
+	if len(trace) < 4 {
+		t.Fatalf("Expected a stack trace, got %v", trace)
+	}
+
+	expected := []string{".panicLevelThree", ".panicLevelTwo", ".panicLevelOne", ".recoverPanic"}
+	for i, suffix := range expected {
+		if !strings.HasSuffix(trace[i].Function, suffix) {
+			t.Errorf("Expected frame %d to be %s, got %s", i, suffix, trace[i].Function)
+		}
+	}
+}
+
+func TestRecoverRuntimeErrorStackTrace(t *testing.T) {
+	err := func() (err error) {
+		defer Recover(&err)
+		var values []int
+		_ = values[3]
+		return nil
+	}()
+
+	trace := err.(*ManagedError).StackTrace()
+	if len(trace) == 0 || !strings.HasSuffix(trace[0].Function, ".TestRecoverRuntimeErrorStackTrace.func1") {
+		t.Errorf("Expected top frame to be the panicking function, got %v", trace)
+	}
+}
@@ -0,0 +1,73 @@
+package errmgt
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Renderer formats a ManagedError for a particular output target
+type Renderer interface {
+	Render(e *ManagedError) string
+}
+
+// RenderWith formats the error with r
+func (e *ManagedError) RenderWith(r Renderer) string {
+	return r.Render(e)
+}
+
+// TextRenderer renders errors as plain text: the Error() string, or the
+// multi-line %+v form when Verbose is set
+type TextRenderer struct {
+	Verbose bool
+}
+
+// Render implements Renderer
+func (r TextRenderer) Render(e *ManagedError) string {
+	if r.Verbose {
+		return fmt.Sprintf("%+v", e)
+	}
+	return e.Error()
+}
+
+// JSONRenderer renders errors as JSON, indented with Indent when it is set
+type JSONRenderer struct {
+	Indent string
+}
+
+// Render implements Renderer. It renders "null" if the error cannot be
+// encoded, which only happens when its Metadata is not serializable.
+func (r JSONRenderer) Render(e *ManagedError) string {
+	var data []byte
+	var err error
+	if r.Indent != "" {
+		data, err = json.MarshalIndent(e, "", r.Indent)
+	} else {
+		data, err = json.Marshal(e)
+	}
+	if err != nil {
+		return "null"
+	}
+	return string(data)
+}
+
+// severityColors maps severities to ANSI SGR color codes
+var severityColors = map[Severity]string{
+	SeverityInfo:     "36",
+	SeverityWarning:  "33",
+	SeverityError:    "31",
+	SeverityCritical: "1;31",
+}
+
+// ColorRenderer renders the Error() string wrapped in ANSI escape codes
+// that color it by severity for terminals: cyan for info, yellow for
+// warnings, red for errors and bold red for critical errors
+type ColorRenderer struct{}
+
+// Render implements Renderer
+func (ColorRenderer) Render(e *ManagedError) string {
+	color, ok := severityColors[GetSeverity(e)]
+	if !ok {
+		return e.Error()
+	}
+	return "\x1b[" + color + "m" + e.Error() + "\x1b[0m"
+}
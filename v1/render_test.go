@@ -0,0 +1,58 @@
+package errmgt
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestTextRenderer(t *testing.T) {
+	err := NewErrorWithCause(SystemError, "db_error", "Database error", NewError(ExternalError, "dial_failed", "Dial failed"))
+
+	if got := err.RenderWith(TextRenderer{}); got != err.Error() {
+		t.Errorf("Expected Error() output, got %q", got)
+	}
+
+	if got := err.RenderWith(TextRenderer{Verbose: true}); !strings.Contains(got, "\ncaused by: [external:dial_failed] Dial failed") {
+		t.Errorf("Expected verbose output, got %q", got)
+	}
+}
+
+func TestJSONRenderer(t *testing.T) {
+	err := NewError(ValidationError, "invalid_email", "Invalid email")
+
+	compact := err.RenderWith(JSONRenderer{})
+	var decoded ManagedError
+	if unmarshalErr := json.Unmarshal([]byte(compact), &decoded); unmarshalErr != nil || decoded.Code != "invalid_email" {
+		t.Errorf("Expected valid JSON, got %s: %v", compact, unmarshalErr)
+	}
+
+	if indented := err.RenderWith(JSONRenderer{Indent: "  "}); !strings.Contains(indented, "\n  \"code\": \"invalid_email\"") {
+		t.Errorf("Expected indented JSON, got %s", indented)
+	}
+
+	unencodable := NewError(ValidationError, "invalid_email", "Invalid email").WithMetadata(func() {})
+	if got := unencodable.RenderWith(JSONRenderer{}); got != "null" {
+		t.Errorf("Expected null for unencodable errors, got %s", got)
+	}
+}
+
+func TestColorRenderer(t *testing.T) {
+	tests := []struct {
+		severity Severity
+		expected string
+	}{
+		{SeverityInfo, "\x1b[36m"},
+		{SeverityWarning, "\x1b[33m"},
+		{0, "\x1b[31m"},
+		{SeverityCritical, "\x1b[1;31m"},
+	}
+
+	for _, tt := range tests {
+		err := NewError(SystemError, "db_error", "Database error").WithSeverity(tt.severity)
+		expected := tt.expected + err.Error() + "\x1b[0m"
+		if got := err.RenderWith(ColorRenderer{}); got != expected {
+			t.Errorf("Render(%v) = %q, want %q", tt.severity, got, expected)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package errmgt
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// NotFoundCode is the error code used by NotFound
+const NotFoundCode Code = "not_found"
+
+// WithResource records the kind and identifier of the resource the error
+// concerns, such as "user" and "123"
+func (e *ManagedError) WithResource(resourceType, id string) *ManagedError {
+	if e == nil {
+		return nil
+	}
+	e.ResourceType = resourceType
+	e.ResourceID = id
+	return e
+}
+
+// NotFound creates a BusinessError with code NotFoundCode and status 404
+// for the resource of the given kind and identifier, e.g. "user 123 not
+// found"
+func NotFound(resourceType, id string) *ManagedError {
+	return newError(1, BusinessError, NotFoundCode, fmt.Sprintf("%s %s not found", resourceType, id), nil).
+		WithResource(resourceType, id).
+		WithStatusCode(http.StatusNotFound)
+}
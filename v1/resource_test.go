@@ -0,0 +1,43 @@
+package errmgt
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestNotFound(t *testing.T) {
+	err := NotFound("user", "123")
+
+	if err.Type != BusinessError || err.Code != NotFoundCode || err.StatusCode != 404 {
+		t.Errorf("Expected a 404 business error with code %s, got %+v", NotFoundCode, err)
+	}
+
+	if err.Message != "user 123 not found" {
+		t.Errorf("Expected message 'user 123 not found', got '%s'", err.Message)
+	}
+
+	if err.ResourceType != "user" || err.ResourceID != "123" {
+		t.Errorf("Expected resource user/123, got %s/%s", err.ResourceType, err.ResourceID)
+	}
+}
+
+func TestManagedErrorWithResource(t *testing.T) {
+	err := NewError(BusinessError, "permission_denied", "Permission denied").WithResource("invoice", "inv-7")
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("Failed to marshal: %v", marshalErr)
+	}
+	if !strings.Contains(string(data), `"resource_type":"invoice","resource_id":"inv-7"`) {
+		t.Errorf("Expected resource in JSON, got %s", data)
+	}
+
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Error("denied", "error", err)
+	if !strings.Contains(buf.String(), "error.resource.type=invoice error.resource.id=inv-7") {
+		t.Errorf("Expected resource in log output, got %s", buf.String())
+	}
+}
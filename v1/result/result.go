@@ -0,0 +1,61 @@
+// Package result provides a generic Result type that carries either a value
+// or a ManagedError, for composing multi-stage processing pipelines.
+package result
+
+import (
+	"github.com/kerzzt/go-errmgt"
+)
+
+// Result holds either a value of type T or a ManagedError
+type Result[T any] struct {
+	value T
+	err   *errmgt.ManagedError
+}
+
+// Ok returns a successful Result holding value
+func Ok[T any](value T) Result[T] {
+	return Result[T]{value: value}
+}
+
+// Err returns a failed Result holding err. Passing a nil error yields a
+// successful Result holding the zero value of T.
+func Err[T any](err *errmgt.ManagedError) Result[T] {
+	return Result[T]{err: err}
+}
+
+// IsOk reports whether the Result holds a value rather than an error
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// Err returns the error held by the Result, or nil if it is successful
+func (r Result[T]) Err() *errmgt.ManagedError {
+	return r.err
+}
+
+// Unwrap returns the value and error held by the Result. The returned error
+// is a true nil interface when the Result is successful.
+func (r Result[T]) Unwrap() (T, error) {
+	if r.err != nil {
+		return r.value, r.err
+	}
+	return r.value, nil
+}
+
+// Map applies f to the value of a successful Result. A failed Result is
+// returned unchanged without calling f.
+func Map[T, U any](r Result[T], f func(T) U) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return Ok(f(r.value))
+}
+
+// FlatMap applies f, which may itself fail, to the value of a successful
+// Result. A failed Result is returned unchanged without calling f.
+func FlatMap[T, U any](r Result[T], f func(T) Result[U]) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return f(r.value)
+}
@@ -0,0 +1,90 @@
+package result
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/kerzzt/go-errmgt"
+)
+
+func parse(s string) Result[int] {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return Err[int](errmgt.NewErrorWithCause(errmgt.ValidationError, "invalid_number", "Invalid number", err))
+	}
+	return Ok(n)
+}
+
+func TestOk(t *testing.T) {
+	r := Ok(42)
+
+	if !r.IsOk() {
+		t.Error("Expected result to be ok")
+	}
+
+	value, err := r.Unwrap()
+	if value != 42 || err != nil {
+		t.Errorf("Unwrap() = %v, %v, want 42, nil", value, err)
+	}
+
+	if r.Err() != nil {
+		t.Error("Expected no error")
+	}
+}
+
+func TestErr(t *testing.T) {
+	managedErr := errmgt.NewError(errmgt.ValidationError, "invalid_input", "Invalid input")
+	r := Err[int](managedErr)
+
+	if r.IsOk() {
+		t.Error("Expected result not to be ok")
+	}
+
+	if _, err := r.Unwrap(); err != managedErr {
+		t.Errorf("Expected error %v, got %v", managedErr, err)
+	}
+
+	if r.Err() != managedErr {
+		t.Errorf("Expected error %v, got %v", managedErr, r.Err())
+	}
+}
+
+func TestMap(t *testing.T) {
+	doubled := Map(parse("21"), func(n int) int { return n * 2 })
+	if value, err := doubled.Unwrap(); value != 42 || err != nil {
+		t.Errorf("Unwrap() = %v, %v, want 42, nil", value, err)
+	}
+
+	called := false
+	failed := Map(parse("abc"), func(n int) string {
+		called = true
+		return strconv.Itoa(n)
+	})
+	if called {
+		t.Error("Expected Map not to call f on a failed result")
+	}
+	if failed.IsOk() || failed.Err().Code != "invalid_number" {
+		t.Errorf("Expected failure to propagate, got %v", failed.Err())
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	positive := func(n int) Result[int] {
+		if n <= 0 {
+			return Err[int](errmgt.NewError(errmgt.ValidationError, "not_positive", "Number must be positive"))
+		}
+		return Ok(n)
+	}
+
+	if value, err := FlatMap(parse("5"), positive).Unwrap(); value != 5 || err != nil {
+		t.Errorf("Unwrap() = %v, %v, want 5, nil", value, err)
+	}
+
+	if r := FlatMap(parse("-5"), positive); r.IsOk() || r.Err().Code != "not_positive" {
+		t.Errorf("Expected not_positive error, got %v", r.Err())
+	}
+
+	if r := FlatMap(parse("abc"), positive); r.IsOk() || r.Err().Code != "invalid_number" {
+		t.Errorf("Expected invalid_number error, got %v", r.Err())
+	}
+}
@@ -0,0 +1,139 @@
+package errmgt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// RetryOptions configures RetryWithTimeout
+type RetryOptions struct {
+	// MaxAttempts is the maximum number of attempts. Values below 1 mean
+	// a single attempt.
+	MaxAttempts int
+	// AttemptTimeout bounds each attempt. Zero leaves attempts bounded
+	// only by the parent context.
+	AttemptTimeout time.Duration
+	// Delay is the wait between attempts. An error's RetryAfter is used
+	// instead when it is longer.
+	Delay time.Duration
+	// CollectErrors makes RetryWithTimeout return a MultiError of every
+	// attempt's error instead of only the last one.
+	CollectErrors bool
+}
+
+// RetryWithTimeout calls fn until it succeeds, returns an error that is
+// not retryable according to IsRetryable, or opts.MaxAttempts is reached.
+// Each attempt's error is annotated with its Attempt number and with
+// "attempt" and "max_attempts" context entries; a ManagedError is copied
+// rather than modified, and any other error is wrapped with WrapPreserving.
+// Each attempt gets its own context derived from ctx with
+// opts.AttemptTimeout, while ctx bounds the whole operation: when it is
+// done, no further attempts are made and its error is reported alongside
+// the last attempt's error, or every attempt's error with CollectErrors,
+// in a MultiError. An attempt that fails because its own timeout
+// expired is reported as a retryable ExternalError with code TimeoutCode,
+// wrapping what fn returned.
+func RetryWithTimeout(ctx context.Context, opts RetryOptions, fn func(context.Context) error) error {
+	maxAttempts := max(opts.MaxAttempts, 1)
+
+	var errs []error
+	for attempt := 1; ; attempt++ {
+		err := runAttempt(ctx, opts.AttemptTimeout, fn)
+		if err == nil {
+			return nil
+		}
+		err = annotateAttempt(err, attempt, maxAttempts)
+		errs = append(errs, err)
+		if attempt >= maxAttempts || !IsRetryable(err) {
+			break
+		}
+		if waitErr := sleepContext(ctx, retryDelay(opts.Delay, err)); waitErr != nil {
+			if !opts.CollectErrors {
+				return Append(err, waitErr)
+			}
+			errs = append(errs, waitErr)
+			break
+		}
+		// A retried attempt never reaches a handler, so report it here
+		ObserveDependency(err)
+	}
+
+	if opts.CollectErrors {
+		return &MultiError{Errors: errs}
+	}
+	return errs[len(errs)-1]
+}
+
+// annotateAttempt records which attempt produced err
+func annotateAttempt(err error, attempt, maxAttempts int) error {
+	annotated, ok := err.(*ManagedError)
+	if ok && annotated != nil {
+		annotated = annotated.clone()
+	} else {
+		code := InternalErrorCode
+		if inner, found := asManaged(err); found {
+			code = inner.Code
+		}
+		annotated = WrapPreserving(err, code, err.Error())
+	}
+	return annotated.
+		WithAttempt(attempt).
+		WithContext("attempt", strconv.Itoa(attempt)).
+		WithContext("max_attempts", strconv.Itoa(maxAttempts))
+}
+
+// WithAttempt records which attempt of a retried operation failed
+func (e *ManagedError) WithAttempt(attempt int) *ManagedError {
+	if e == nil {
+		return nil
+	}
+	e.Attempt = attempt
+	return e
+}
+
+// runAttempt calls fn once with a context bounded by timeout
+func runAttempt(ctx context.Context, timeout time.Duration, fn func(context.Context) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := fn(attemptCtx)
+	if err != nil && ctx.Err() == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded) && !IsRetryable(err) {
+		return newError(1, ExternalError, TimeoutCode, fmt.Sprintf("attempt timed out after %s", timeout), err).
+			WithRetryable(true)
+	}
+	return err
+}
+
+// retryDelay returns how long to wait after err before the next attempt
+func retryDelay(delay time.Duration, err error) time.Duration {
+	if managedErr, ok := asManaged(err); ok && managedErr.RetryAfter > delay {
+		return managedErr.RetryAfter
+	}
+	return delay
+}
+
+// sleepContext waits for d or until ctx is done, returning ctx's error in
+// the latter case
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,187 @@
+package errmgt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRetryWithTimeoutSucceeds(t *testing.T) {
+	calls := 0
+	err := RetryWithTimeout(context.Background(), RetryOptions{MaxAttempts: 3}, func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return NewError(ExternalError, "unavailable", "Unavailable").WithRetryable(true)
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Errorf("Expected success, got %v", err)
+	}
+
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestRetryWithTimeoutNonRetryable(t *testing.T) {
+	calls := 0
+	failure := NewError(ValidationError, "invalid_input", "Invalid input")
+	err := RetryWithTimeout(context.Background(), RetryOptions{MaxAttempts: 3}, func(context.Context) error {
+		calls++
+		return failure
+	})
+
+	if !errors.Is(err, failure) {
+		t.Errorf("Expected the non-retryable error, got %v", err)
+	}
+
+	if failure.Attempt != 0 || failure.Context != nil {
+		t.Error("Expected the error returned by fn not to be modified")
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected a single attempt, got %d", calls)
+	}
+}
+
+func TestRetryWithTimeoutCollectErrors(t *testing.T) {
+	calls := 0
+	err := RetryWithTimeout(context.Background(), RetryOptions{MaxAttempts: 2, CollectErrors: true}, func(context.Context) error {
+		calls++
+		return NewError(ExternalError, "unavailable", "Unavailable").WithRetryable(true)
+	})
+
+	var multi *MultiError
+	if !errors.As(err, &multi) || len(multi.Errors) != 2 {
+		t.Fatalf("Expected a MultiError of 2 attempts, got %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("Expected 2 attempts, got %d", calls)
+	}
+}
+
+func TestRetryWithTimeoutAttemptTimeout(t *testing.T) {
+	calls := 0
+	opts := RetryOptions{MaxAttempts: 2, AttemptTimeout: 10 * time.Millisecond}
+	err := RetryWithTimeout(context.Background(), opts, func(ctx context.Context) error {
+		calls++
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	if calls != 2 {
+		t.Errorf("Expected timed out attempts to be retried, got %d attempts", calls)
+	}
+
+	var managedErr *ManagedError
+	if !errors.As(err, &managedErr) || managedErr.Code != TimeoutCode {
+		t.Fatalf("Expected a timeout error, got %v", err)
+	}
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("Expected the attempt's error to be kept as the cause")
+	}
+}
+
+func TestRetryWithTimeoutParentCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	calls := 0
+	opts := RetryOptions{MaxAttempts: 5, Delay: time.Hour, CollectErrors: true}
+	err := RetryWithTimeout(ctx, opts, func(context.Context) error {
+		calls++
+		cancel()
+		return NewError(ExternalError, "unavailable", "Unavailable").WithRetryable(true)
+	})
+
+	if calls != 1 {
+		t.Errorf("Expected no attempts after cancellation, got %d", calls)
+	}
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the cancellation to be reported, got %v", err)
+	}
+
+	if !errors.Is(err, NewError(ExternalError, "unavailable", "")) {
+		t.Errorf("Expected the attempt error to be reported, got %v", err)
+	}
+}
+
+func TestRetryWithTimeoutParentDeadlineDuringDelay(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	opts := RetryOptions{MaxAttempts: 5, Delay: time.Hour}
+	err := RetryWithTimeout(ctx, opts, func(context.Context) error {
+		return NewError(ExternalError, "unavailable", "Unavailable").WithRetryable(true)
+	})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the deadline to be reported, got %v", err)
+	}
+
+	var attemptErr *ManagedError
+	if !errors.As(err, &attemptErr) || attemptErr.Code != "unavailable" || attemptErr.Attempt != 1 {
+		t.Errorf("Expected the last attempt's error to be reported, got %v", err)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	err := NewError(ExternalError, "rate_limited", "Rate limited").WithRetryAfter(time.Second)
+
+	if d := retryDelay(10*time.Millisecond, err); d != time.Second {
+		t.Errorf("Expected RetryAfter to take precedence, got %s", d)
+	}
+
+	if d := retryDelay(2*time.Second, err); d != 2*time.Second {
+		t.Errorf("Expected the longer delay, got %s", d)
+	}
+
+	if d := retryDelay(time.Millisecond, errors.New("plain")); d != time.Millisecond {
+		t.Errorf("Expected the configured delay for plain errors, got %s", d)
+	}
+}
+
+func TestRetryWithTimeoutAnnotatesAttempts(t *testing.T) {
+	opts := RetryOptions{MaxAttempts: 3}
+
+	managed := RetryWithTimeout(context.Background(), opts, func(context.Context) error {
+		return NewError(ExternalError, "unavailable", "Unavailable").WithRetryable(true)
+	})
+
+	var managedErr *ManagedError
+	if !errors.As(managed, &managedErr) {
+		t.Fatalf("Expected a ManagedError, got %T", managed)
+	}
+	if managedErr.Attempt != 3 || managedErr.Context["attempt"] != "3" || managedErr.Context["max_attempts"] != "3" {
+		t.Errorf("Expected attempt 3/3, got %d and %v", managedErr.Attempt, managedErr.Context)
+	}
+
+	plain := errors.New("disk full")
+	wrapped := RetryWithTimeout(context.Background(), opts, func(context.Context) error {
+		return fmt.Errorf("save: %w", plain)
+	})
+
+	if !errors.As(wrapped, &managedErr) || managedErr.Attempt != 1 || managedErr.Context["max_attempts"] != "3" {
+		t.Fatalf("Expected a plain error to be annotated, got %v", wrapped)
+	}
+	if managedErr.Code != InternalErrorCode || managedErr.Message != "save: disk full" || !errors.Is(wrapped, plain) {
+		t.Errorf("Expected the plain error to be wrapped, got %v", managedErr)
+	}
+
+	retryable := NewError(ExternalError, "unavailable", "Unavailable").WithRetryable(true)
+	calls := 0
+	wrappedManaged := RetryWithTimeout(context.Background(), RetryOptions{MaxAttempts: 2}, func(context.Context) error {
+		calls++
+		return fmt.Errorf("client: %w", retryable)
+	})
+
+	if calls != 2 || !errors.As(wrappedManaged, &managedErr) || managedErr.Code != "unavailable" || managedErr.Attempt != 2 {
+		t.Errorf("Expected a wrapped ManagedError to stay retryable and be annotated, got %v after %d calls", wrappedManaged, calls)
+	}
+}
@@ -0,0 +1,124 @@
+package errmgt
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Sampler limits how often equivalent errors are let through. Errors are
+// considered equivalent under the same rules as Deduplicator. It is safe
+// for concurrent use.
+type Sampler struct {
+	limit    int
+	interval time.Duration
+	now      func() time.Time
+
+	mu        sync.Mutex
+	windows   map[uint64]*sampleWindow
+	lastSweep time.Time
+}
+
+// sampleWindow counts the errors allowed since start
+type sampleWindow struct {
+	start time.Time
+	count int
+}
+
+// NewSampler creates a Sampler that allows the first limit equivalent
+// errors in each interval and suppresses the rest. The interval for an
+// error starts when it is first allowed.
+func NewSampler(limit int, interval time.Duration) *Sampler {
+	return &Sampler{
+		limit:    limit,
+		interval: interval,
+		now:      currentTime,
+		windows:  make(map[uint64]*sampleWindow),
+	}
+}
+
+// Allow reports whether err should be let through. It always returns true
+// for a nil error.
+func (s *Sampler) Allow(err error) bool {
+	if err == nil {
+		return true
+	}
+	key := dedupKey(err)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	window, ok := s.windows[key]
+	if !ok || now.Sub(window.start) >= s.interval {
+		window = &sampleWindow{start: now}
+		s.windows[key] = window
+	}
+	if window.count >= s.limit {
+		return false
+	}
+	window.count++
+	return true
+}
+
+// sweep evicts expired windows, at most once per interval
+func (s *Sampler) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < s.interval {
+		return
+	}
+	for key, window := range s.windows {
+		if now.Sub(window.start) >= s.interval {
+			delete(s.windows, key)
+		}
+	}
+	s.lastSweep = now
+}
+
+// throttledHandler is the slog.Handler returned by NewThrottledHandler
+type throttledHandler struct {
+	next    slog.Handler
+	sampler *Sampler
+}
+
+// NewThrottledHandler wraps next in a handler that drops records carrying
+// a ManagedError the sampler suppresses. The first attribute of the record
+// whose value is an error containing a ManagedError is consulted; records
+// without one are always passed to next. Attributes added with WithAttrs
+// are not inspected.
+func NewThrottledHandler(next slog.Handler, sampler *Sampler) slog.Handler {
+	return &throttledHandler{next: next, sampler: sampler}
+}
+
+// Enabled implements slog.Handler
+func (h *throttledHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler
+func (h *throttledHandler) Handle(ctx context.Context, r slog.Record) error {
+	var managedErr *ManagedError
+	r.Attrs(func(a slog.Attr) bool {
+		if err, ok := a.Value.Any().(error); ok {
+			managedErr, ok = asManaged(err)
+			return !ok
+		}
+		return true
+	})
+	if managedErr != nil && !h.sampler.Allow(managedErr) {
+		return nil
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs implements slog.Handler
+func (h *throttledHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &throttledHandler{next: h.next.WithAttrs(attrs), sampler: h.sampler}
+}
+
+// WithGroup implements slog.Handler
+func (h *throttledHandler) WithGroup(name string) slog.Handler {
+	return &throttledHandler{next: h.next.WithGroup(name), sampler: h.sampler}
+}
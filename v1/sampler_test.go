@@ -0,0 +1,85 @@
+package errmgt
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestSampler(limit int, interval time.Duration) (*Sampler, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewSampler(limit, interval)
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func TestSamplerAllow(t *testing.T) {
+	s, now := newTestSampler(2, time.Minute)
+
+	err := NewError(ExternalError, "api_timeout", "API timeout")
+	for i := 0; i < 2; i++ {
+		if !s.Allow(err) {
+			t.Errorf("Expected occurrence %d to be allowed", i+1)
+		}
+	}
+
+	if s.Allow(NewError(ExternalError, "api_timeout", "Different message")) {
+		t.Error("Expected equivalent error over the limit to be suppressed")
+	}
+
+	if !s.Allow(NewError(ExternalError, "api_unavailable", "API unavailable")) {
+		t.Error("Expected error with a different code to be allowed")
+	}
+
+	*now = now.Add(time.Minute)
+	if !s.Allow(err) {
+		t.Error("Expected error to be allowed after the interval expired")
+	}
+
+	if !s.Allow(nil) {
+		t.Error("Expected nil to be allowed")
+	}
+}
+
+func TestSamplerSweep(t *testing.T) {
+	s, now := newTestSampler(1, time.Minute)
+
+	s.Allow(errors.New("first"))
+	*now = now.Add(time.Minute)
+	s.Allow(errors.New("second"))
+
+	if len(s.windows) != 1 {
+		t.Errorf("Expected expired windows to be swept, got %d", len(s.windows))
+	}
+}
+
+func TestNewThrottledHandler(t *testing.T) {
+	var buf bytes.Buffer
+	sampler, _ := newTestSampler(1, time.Minute)
+	logger := slog.New(NewThrottledHandler(slog.NewTextHandler(&buf, nil), sampler)).With("service", "users")
+
+	err := NewError(ExternalError, "api_timeout", "API timeout")
+	for i := 0; i < 3; i++ {
+		logger.Error("request failed", "attempt", i, "error", fmt.Errorf("fetch: %w", err))
+	}
+	logger.Error("plain failure", "error", errors.New("disk full"))
+	logger.Error("plain failure", "error", errors.New("disk full"))
+	logger.WithGroup("request").Info("no error")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected 4 log lines, got %d:\n%s", len(lines), buf.String())
+	}
+
+	if !strings.Contains(lines[0], "attempt=0") || !strings.Contains(lines[0], "service=users") {
+		t.Errorf("Expected the first occurrence to be logged, got %s", lines[0])
+	}
+
+	if !strings.Contains(lines[3], "no error") {
+		t.Errorf("Expected records without errors to pass through, got %s", lines[3])
+	}
+}
@@ -0,0 +1,121 @@
+package errmgt
+
+// WithPublicContextKeys marks context keys as safe to keep when the error
+// is sanitized
+func (e *ManagedError) WithPublicContextKeys(keys ...string) *ManagedError {
+	if e == nil {
+		return nil
+	}
+	if e.publicContextKeys == nil {
+		e.publicContextKeys = make(map[string]bool, len(keys))
+	}
+	for _, key := range keys {
+		e.publicContextKeys[key] = true
+	}
+	return e
+}
+
+// MarkBoundary marks the error as the point where it crossed from internal
+// to external handling. Sanitized keeps the ManagedErrors above the
+// nearest boundary in the chain and strips everything below it.
+func (e *ManagedError) MarkBoundary() *ManagedError {
+	if e == nil {
+		return nil
+	}
+	e.Boundary = true
+	return e
+}
+
+// CrossedBoundary reports whether any ManagedError in err's chain was
+// marked with MarkBoundary
+func CrossedBoundary(err error) bool {
+	crossed := false
+	walkChain(err, func(e error) {
+		if managedErr, ok := e.(*ManagedError); ok && managedErr != nil && managedErr.Boundary {
+			crossed = true
+		}
+	})
+	return crossed
+}
+
+// Sanitized returns a copy of the error that is safe to return across a
+// trust boundary. The copy is built from an allow-list: it keeps the ID,
+// Type, Code, Message, UserMessage, StatusCode, Retryable, RetryAfter and
+// Boundary, and the Context entries whose keys were marked with
+// WithPublicContextKeys. Every other field is left empty, so fields added
+// to ManagedError later stay internal until they are allowed here. The
+// copy has no Cause unless a ManagedError in the
+// chain below it was marked with MarkBoundary; the ManagedErrors down to
+// the nearest such boundary are then kept, sanitized the same way, while
+// other errors in between and everything below the boundary are dropped.
+// A nil error sanitizes to nil.
+func (e *ManagedError) Sanitized() *ManagedError {
+	if e == nil {
+		return nil
+	}
+	sanitized := e.sanitizedCopy()
+	if !e.Boundary {
+		sanitized.Cause = sanitizedChain(e.Cause)
+	}
+	return sanitized
+}
+
+// sanitizedChain returns sanitized copies of the ManagedErrors in err's
+// chain down to the nearest boundary, linked as causes. It returns nil
+// when no boundary is found.
+func sanitizedChain(err error) error {
+	var kept []*ManagedError
+	found := false
+	walkChain(err, func(e error) {
+		if managedErr, ok := e.(*ManagedError); ok && managedErr != nil && !found {
+			kept = append(kept, managedErr)
+			found = managedErr.Boundary
+		}
+	})
+	if !found {
+		return nil
+	}
+
+	var cause error
+	for i := len(kept) - 1; i >= 0; i-- {
+		sanitized := kept[i].sanitizedCopy()
+		sanitized.Cause = cause
+		cause = sanitized
+	}
+	return cause
+}
+
+// sanitizedCopy returns a copy of the error holding only the allowed
+// fields, without a cause
+func (e *ManagedError) sanitizedCopy() *ManagedError {
+	sanitized := &ManagedError{
+		ID:          e.ID,
+		idSeed:      e.idSeed,
+		Type:        e.Type,
+		Code:        e.Code,
+		Message:     e.GetMessage(),
+		UserMessage: e.UserMessage,
+		StatusCode:  e.StatusCode,
+		Retryable:   e.Retryable,
+		RetryAfter:  e.RetryAfter,
+		Boundary:    e.Boundary,
+	}
+
+	// Public keys are copied directly rather than through WithContext, so
+	// the MaxContextKeys limit cannot drop an arbitrary subset of them
+	for key := range e.publicContextKeys {
+		if value, exists := e.Context[key]; exists {
+			if sanitized.Context == nil {
+				sanitized.Context = make(map[string]string, len(e.publicContextKeys))
+			}
+			sanitized.Context[key] = value
+		}
+	}
+	if len(e.publicContextKeys) > 0 {
+		sanitized.publicContextKeys = make(map[string]bool, len(e.publicContextKeys))
+		for key := range e.publicContextKeys {
+			sanitized.publicContextKeys[key] = true
+		}
+	}
+	return sanitized
+}
@@ -0,0 +1,190 @@
+package errmgt
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestManagedErrorSanitized(t *testing.T) {
+	SetCaptureStack(true)
+	defer SetCaptureStack(false)
+	SetCaptureCaller(true)
+	defer SetCaptureCaller(false)
+
+	err := NewErrorWithCause(SystemError, "db_error", "Database error", errors.New("table users locked")).
+		WithDetails("SELECT * FROM users").
+		WithContext("table", "users").
+		WithContext("request_id", "req-123").
+		WithPublicContextKeys("request_id").
+		WithStatusCode(500).
+		WithRetryable(true).
+		WithMetadata(map[string]string{"query": "SELECT * FROM users"}).
+		WithDependency("users-db")
+
+	sanitized := err.Sanitized()
+
+	if sanitized == err {
+		t.Fatal("Expected Sanitized to return a copy")
+	}
+
+	if sanitized.Type != SystemError || sanitized.Code != "db_error" || sanitized.Message != "Database error" {
+		t.Errorf("Expected type, code and message to be kept, got %v", sanitized)
+	}
+
+	if sanitized.StatusCode != 500 || !sanitized.Retryable {
+		t.Error("Expected status code and retryable to be kept")
+	}
+
+	if sanitized.Cause != nil {
+		t.Error("Expected cause to be cleared")
+	}
+
+	if sanitized.Details != "" {
+		t.Error("Expected details to be cleared")
+	}
+
+	if sanitized.StackTrace() != nil {
+		t.Error("Expected stack trace to be cleared")
+	}
+
+	if sanitized.Caller != "" {
+		t.Error("Expected caller to be cleared")
+	}
+
+	if sanitized.Metadata != nil {
+		t.Error("Expected metadata to be cleared")
+	}
+
+	if sanitized.Dependency != "" {
+		t.Error("Expected dependency to be cleared")
+	}
+
+	if len(sanitized.Context) != 1 || sanitized.Context["request_id"] != "req-123" {
+		t.Errorf("Expected only public context to be kept, got %v", sanitized.Context)
+	}
+
+	// The original is unchanged
+	if err.Cause == nil || err.Details == "" || len(err.Context) != 2 {
+		t.Error("Expected the original error to be unchanged")
+	}
+
+	sanitized.WithContext("extra", "value")
+	if _, exists := err.Context["extra"]; exists {
+		t.Error("Expected the copy not to share context with the original")
+	}
+}
+
+func TestManagedErrorSanitizedAllowList(t *testing.T) {
+	err := NewError(BusinessError, "quota_exceeded", "Quota exceeded").
+		WithUserMessage("You have used your monthly quota").
+		WithRetryAfter(time.Hour).
+		WithContext("tenant", "acme").
+		WithFingerprint("quota", "acme").
+		WithComponent("billing").
+		WithScope("billing.quota").
+		WithResource("tenant", "acme").
+		WithSpanContext("4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7").
+		WithSuggestedAction("Raise the tenant's quota").
+		WithTags("internal").
+		WithSeverity(SeverityCritical)
+
+	sanitized := err.Sanitized()
+
+	want := &ManagedError{
+		ID:          err.GetID(),
+		Type:        BusinessError,
+		Code:        "quota_exceeded",
+		Message:     "Quota exceeded",
+		UserMessage: "You have used your monthly quota",
+		RetryAfter:  time.Hour,
+	}
+	got := *sanitized
+	got.ID = got.GetID()
+	got.idSeed = 0
+	if !reflect.DeepEqual(&got, want) {
+		t.Errorf("Sanitized() = %+v, want only the allowed fields %+v", got, *want)
+	}
+}
+
+func TestManagedErrorSanitizedPublicKeysOverLimit(t *testing.T) {
+	err := NewError(SystemError, "db_error", "Database error").
+		WithContext("request_id", "req-123").
+		WithContext("tenant", "acme").
+		WithContext("region", "eu-1").
+		WithPublicContextKeys("request_id", "tenant", "region")
+
+	SetMaxContextKeys(1)
+	defer SetMaxContextKeys(0)
+
+	expected := map[string]string{"request_id": "req-123", "tenant": "acme", "region": "eu-1"}
+	if got := err.Sanitized().Context; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected every public key to be kept, got %v", got)
+	}
+}
+
+func TestManagedErrorSanitizedNoPublicKeys(t *testing.T) {
+	err := NewError(SystemError, "db_error", "Database error").
+		WithContext("table", "users")
+
+	if sanitized := err.Sanitized(); len(sanitized.Context) != 0 {
+		t.Errorf("Expected no context to be kept, got %v", sanitized.Context)
+	}
+}
+
+func TestManagedErrorMarkBoundary(t *testing.T) {
+	internal := NewErrorWithCause(SystemError, "db_error", "Database error", errors.New("table users locked"))
+	boundary := NewErrorWithCause(BusinessError, "signup_failed", "Signup failed", internal).
+		WithDetails("could not persist user").
+		WithContext("request_id", "req-123").
+		WithPublicContextKeys("request_id").
+		MarkBoundary()
+	err := NewErrorWithCause(ValidationError, "invalid_signup", "Invalid signup", fmt.Errorf("handler: %w", boundary))
+
+	if !CrossedBoundary(err) {
+		t.Error("Expected the chain to have crossed a boundary")
+	}
+
+	if CrossedBoundary(internal) {
+		t.Error("Expected errors below the boundary not to have crossed it")
+	}
+
+	sanitized := err.Sanitized()
+
+	kept, ok := sanitized.Cause.(*ManagedError)
+	if !ok {
+		t.Fatalf("Expected the boundary to be kept as the direct cause, got %T", sanitized.Cause)
+	}
+
+	if kept == boundary || kept.Code != "signup_failed" || !kept.Boundary {
+		t.Errorf("Expected a sanitized copy of the boundary, got %+v", kept)
+	}
+
+	if kept.Details != "" || len(kept.Context) != 1 || kept.Context["request_id"] != "req-123" {
+		t.Errorf("Expected the boundary to be sanitized, got %+v", kept)
+	}
+
+	if kept.Cause != nil {
+		t.Errorf("Expected everything below the boundary to be stripped, got %v", kept.Cause)
+	}
+
+	if errors.Is(sanitized, internal) {
+		t.Error("Expected internal errors not to be reachable from the sanitized error")
+	}
+
+	if boundary.Cause != internal {
+		t.Error("Expected the original chain to be unchanged")
+	}
+}
+
+func TestManagedErrorSanitizedBoundaryItself(t *testing.T) {
+	err := NewErrorWithCause(BusinessError, "signup_failed", "Signup failed",
+		NewError(SystemError, "db_error", "Database error").MarkBoundary()).
+		MarkBoundary()
+
+	if sanitized := err.Sanitized(); sanitized.Cause != nil {
+		t.Errorf("Expected a boundary to strip its own causes, got %v", sanitized.Cause)
+	}
+}
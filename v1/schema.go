@@ -0,0 +1,17 @@
+package errmgt
+
+import (
+	_ "embed"
+)
+
+//go:embed schema.json
+var schema []byte
+
+// JSONSchema returns a JSON Schema document describing the JSON
+// representation of a ManagedError. The returned slice is a copy and
+// may be modified by the caller.
+func JSONSchema() []byte {
+	out := make([]byte, len(schema))
+	copy(out, schema)
+	return out
+}
@@ -0,0 +1,125 @@
+package errmgt
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+)
+
+type jsonSchemaDoc struct {
+	Properties map[string]json.RawMessage `json:"properties"`
+	Required   []string                   `json:"required"`
+}
+
+func parseSchema(t *testing.T) jsonSchemaDoc {
+	t.Helper()
+	var doc jsonSchemaDoc
+	if err := json.Unmarshal(JSONSchema(), &doc); err != nil {
+		t.Fatalf("Failed to parse schema: %v", err)
+	}
+	return doc
+}
+
+func jsonKeys(t *testing.T, err *ManagedError) []string {
+	t.Helper()
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("Failed to marshal error: %v", marshalErr)
+	}
+	var fields map[string]json.RawMessage
+	if unmarshalErr := json.Unmarshal(data, &fields); unmarshalErr != nil {
+		t.Fatalf("Failed to unmarshal error: %v", unmarshalErr)
+	}
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// fullyPopulatedError returns an error with every serializable field set.
+func fullyPopulatedError() *ManagedError {
+	err := NewErrorWithCause(ExternalError, "api_timeout", "API timeout", errors.New("cause")).
+		WithDetails("upstream did not respond").
+		WithUserMessage("The service is temporarily unavailable").
+		WithContext("endpoint", "/users").
+		WithRetryable(true).
+		WithRetryAfter(time.Second).
+		WithStatusCode(504).
+		WithSeverity(SeverityCritical).
+		WithTags("user_facing").
+		WithSpanContext("4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7")
+	err.Caller = "service/user.go:42"
+	err.Occurrences = 47
+	err.HelpURL = "https://docs.example.com/errors/api_timeout"
+	err.Metadata = map[string]interface{}{"attempt": 3}
+	err.Fingerprint = []string{"upstream", "users"}
+	err.SuggestedAction = "Retry after checking the upstream status page"
+	err.Boundary = true
+	err.Dependency = "users-db"
+	err.Priority = 10
+	err.WithResource("user", "42")
+	err.Attempt = 3
+	err.Component = "billing"
+	err.ExpiresAt = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	err.ObservedAt = err.Timestamp.Add(time.Second)
+	err.ContainsPII = true
+	err.Scope = "billing.payment"
+	err.Transient = true
+	err.GoroutineID = 7
+	err.stack = callers(0)
+	return err
+}
+
+func TestJSONSchemaProperties(t *testing.T) {
+	SetIncludeStackInJSON(true)
+	defer SetIncludeStackInJSON(false)
+
+	doc := parseSchema(t)
+
+	properties := make([]string, 0, len(doc.Properties))
+	for k := range doc.Properties {
+		properties = append(properties, k)
+	}
+	sort.Strings(properties)
+
+	emitted := jsonKeys(t, fullyPopulatedError())
+	if len(properties) != len(emitted) {
+		t.Fatalf("Schema properties %v do not match emitted fields %v", properties, emitted)
+	}
+	for i := range properties {
+		if properties[i] != emitted[i] {
+			t.Fatalf("Schema properties %v do not match emitted fields %v", properties, emitted)
+		}
+	}
+}
+
+func TestJSONSchemaRequired(t *testing.T) {
+	doc := parseSchema(t)
+
+	required := append([]string(nil), doc.Required...)
+	sort.Strings(required)
+
+	// A bare error only emits the fields that are never omitted
+	emitted := jsonKeys(t, &ManagedError{})
+	if len(required) != len(emitted) {
+		t.Fatalf("Schema required %v do not match always-emitted fields %v", required, emitted)
+	}
+	for i := range required {
+		if required[i] != emitted[i] {
+			t.Fatalf("Schema required %v do not match always-emitted fields %v", required, emitted)
+		}
+	}
+}
+
+func TestJSONSchemaReturnsCopy(t *testing.T) {
+	first := JSONSchema()
+	first[0] = 'x'
+
+	if JSONSchema()[0] == 'x' {
+		t.Error("Expected JSONSchema to return a copy")
+	}
+}
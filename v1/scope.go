@@ -0,0 +1,48 @@
+package errmgt
+
+// ScopeDelimiter separates the segments of a scope, and the scope from the
+// code in QualifiedCode
+const ScopeDelimiter = "."
+
+// WithScope sets the dotted scope the error belongs to, such as
+// "billing.payment", replacing any existing scope
+func (e *ManagedError) WithScope(scope string) *ManagedError {
+	if e == nil {
+		return nil
+	}
+	e.Scope = scope
+	return e
+}
+
+// PrependScope adds scope in front of the error's existing scope, so a
+// layer handling an error from "charge" with PrependScope("payment")
+// produces "payment.charge". An empty scope is ignored.
+func (e *ManagedError) PrependScope(scope string) *ManagedError {
+	if e == nil {
+		return nil
+	}
+	switch {
+	case scope == "":
+	case e.Scope == "":
+		e.Scope = scope
+	default:
+		e.Scope = scope + ScopeDelimiter + e.Scope
+	}
+	return e
+}
+
+// QualifiedCode returns the code of the first ManagedError in err's chain
+// prefixed with its scope, such as "billing.payment.card_declined". Errors
+// without a scope return their plain code, and errors that are not
+// ManagedErrors return an empty string.
+func QualifiedCode(err error) string {
+	managedErr, ok := asManaged(err)
+	if !ok {
+		return ""
+	}
+	code := managedErr.EffectiveCode()
+	if managedErr.Scope == "" {
+		return string(code)
+	}
+	return managedErr.Scope + ScopeDelimiter + string(code)
+}
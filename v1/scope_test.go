@@ -0,0 +1,54 @@
+package errmgt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestWithScope(t *testing.T) {
+	err := NewError(BusinessError, "card_declined", "Card declined").WithScope("billing.payment")
+
+	if got := QualifiedCode(fmt.Errorf("checkout: %w", err)); got != "billing.payment.card_declined" {
+		t.Errorf("QualifiedCode() = %q", got)
+	}
+
+	if got := QualifiedCode(NewError(BusinessError, "card_declined", "Card declined")); got != "card_declined" {
+		t.Errorf("Expected the plain code without a scope, got %q", got)
+	}
+
+	if got := QualifiedCode(errors.New("plain")); got != "" {
+		t.Errorf("Expected an empty code for a plain error, got %q", got)
+	}
+}
+
+func TestPrependScope(t *testing.T) {
+	err := NewError(BusinessError, "card_declined", "Card declined").
+		PrependScope("charge").
+		PrependScope("").
+		PrependScope("payment").
+		PrependScope("billing")
+
+	if err.Scope != "billing.payment.charge" {
+		t.Errorf("Expected scope 'billing.payment.charge', got '%s'", err.Scope)
+	}
+
+	if got := QualifiedCode(err); got != "billing.payment.charge.card_declined" {
+		t.Errorf("QualifiedCode() = %q", got)
+	}
+}
+
+func TestScopeJSON(t *testing.T) {
+	err := NewError(BusinessError, "card_declined", "Card declined").WithScope("billing")
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("Failed to marshal error: %v", marshalErr)
+	}
+
+	if !strings.Contains(string(data), `"scope":"billing"`) {
+		t.Errorf("Expected scope in JSON, got %s", data)
+	}
+}
@@ -0,0 +1,117 @@
+package errmgt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Severity indicates how serious an error is
+type Severity int
+
+const (
+	// SeverityInfo represents errors that are expected and informational
+	SeverityInfo Severity = iota + 1
+	// SeverityWarning represents errors that may need attention
+	SeverityWarning
+	// SeverityError represents errors that need attention
+	SeverityError
+	// SeverityCritical represents errors that need immediate attention
+	SeverityCritical
+)
+
+// DefaultSeverity is the severity of ManagedErrors without one set
+const DefaultSeverity = SeverityError
+
+// String returns the string representation of the severity
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Besides the names of
+// the defined severities it accepts the "severity(N)" form String uses for
+// custom values, so every Severity survives a round trip through JSON.
+func (s *Severity) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "info":
+		*s = SeverityInfo
+	case "warning":
+		*s = SeverityWarning
+	case "error":
+		*s = SeverityError
+	case "critical":
+		*s = SeverityCritical
+	default:
+		inner, ok := strings.CutPrefix(string(text), "severity(")
+		if inner, ok = strings.CutSuffix(inner, ")"); !ok {
+			return fmt.Errorf("errmgt: unknown severity %q", text)
+		}
+		n, err := strconv.Atoi(inner)
+		if err != nil {
+			return fmt.Errorf("errmgt: unknown severity %q", text)
+		}
+		*s = Severity(n)
+	}
+	return nil
+}
+
+// WithSeverity sets the severity of the error
+func (e *ManagedError) WithSeverity(severity Severity) *ManagedError {
+	if e == nil {
+		return nil
+	}
+	e.Severity = severity
+	return e
+}
+
+// GetSeverity returns the severity of an error. ManagedErrors without a
+// severity report DefaultSeverity, and errors that are not ManagedErrors
+// report SeverityCritical so they are never filtered out.
+func GetSeverity(err error) Severity {
+	if managedErr, ok := asManaged(err); ok {
+		if managedErr.Severity == 0 {
+			return DefaultSeverity
+		}
+		return managedErr.Severity
+	}
+	return SeverityCritical
+}
+
+// AtLeast checks if an error's severity is at or above s
+func AtLeast(err error, s Severity) bool {
+	return GetSeverity(err) >= s
+}
+
+var logThreshold atomic.Int64
+
+// SetLogThreshold sets the minimum severity ShouldLog reports errors for.
+// By default every error is logged.
+func SetLogThreshold(s Severity) {
+	logThreshold.Store(int64(s))
+}
+
+// ShouldLog checks if an error's severity meets the log threshold. It
+// returns false for a nil error.
+func ShouldLog(err error) bool {
+	if err == nil {
+		return false
+	}
+	return AtLeast(err, Severity(logThreshold.Load()))
+}
@@ -0,0 +1,113 @@
+package errmgt
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSeverityString(t *testing.T) {
+	tests := []struct {
+		severity Severity
+		expected string
+	}{
+		{SeverityInfo, "info"},
+		{SeverityWarning, "warning"},
+		{SeverityError, "error"},
+		{SeverityCritical, "critical"},
+		{Severity(99), "severity(99)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := tt.severity.String(); got != tt.expected {
+				t.Errorf("String() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSeverityJSON(t *testing.T) {
+	err := NewError(ValidationError, "invalid_input", "Invalid input").WithSeverity(SeverityWarning)
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("Failed to marshal error: %v", marshalErr)
+	}
+	if !strings.Contains(string(data), `"severity":"warning"`) {
+		t.Errorf("Expected severity in JSON, got %s", data)
+	}
+
+	decoded := roundTripJSON(t, err)
+	if decoded.Severity != SeverityWarning {
+		t.Errorf("Expected decoded severity %s, got %s", SeverityWarning, decoded.Severity)
+	}
+
+	if decoded := roundTripJSON(t, err.WithSeverity(Severity(7))); decoded.Severity != Severity(7) {
+		t.Errorf("Expected a custom severity to round trip, got %s", decoded.Severity)
+	}
+
+	for _, text := range []string{"fatal", "severity(x)", "severity(7", "severity()"} {
+		var invalid ManagedError
+		if unmarshalErr := json.Unmarshal([]byte(`{"severity":"`+text+`"}`), &invalid); unmarshalErr == nil {
+			t.Errorf("Expected error for unknown severity %q", text)
+		}
+	}
+}
+
+func TestGetSeverity(t *testing.T) {
+	if s := GetSeverity(NewError(ValidationError, "invalid_input", "Invalid input")); s != DefaultSeverity {
+		t.Errorf("Expected default severity, got %s", s)
+	}
+
+	err := NewError(ValidationError, "invalid_input", "Invalid input").WithSeverity(SeverityInfo)
+	if s := GetSeverity(Wrap(err, "validating")); s != SeverityInfo {
+		t.Errorf("Expected info severity, got %s", s)
+	}
+
+	if s := GetSeverity(errors.New("plain error")); s != SeverityCritical {
+		t.Errorf("Expected critical severity for plain errors, got %s", s)
+	}
+}
+
+func TestAtLeast(t *testing.T) {
+	err := NewError(ValidationError, "invalid_input", "Invalid input").WithSeverity(SeverityWarning)
+
+	if !AtLeast(err, SeverityInfo) || !AtLeast(err, SeverityWarning) {
+		t.Error("Expected warning to be at least info and warning")
+	}
+
+	if AtLeast(err, SeverityError) {
+		t.Error("Expected warning not to be at least error")
+	}
+}
+
+func TestShouldLog(t *testing.T) {
+	info := NewError(ValidationError, "invalid_input", "Invalid input").WithSeverity(SeverityInfo)
+	critical := NewError(SystemError, "db_down", "Database down").WithSeverity(SeverityCritical)
+	plain := errors.New("plain error")
+
+	if !ShouldLog(info) {
+		t.Error("Expected every error to be logged by default")
+	}
+
+	SetLogThreshold(SeverityError)
+	defer SetLogThreshold(0)
+
+	if ShouldLog(info) {
+		t.Error("Expected info error to be suppressed")
+	}
+
+	if !ShouldLog(critical) {
+		t.Error("Expected critical error to be logged")
+	}
+
+	if !ShouldLog(plain) {
+		t.Error("Expected plain error to be logged")
+	}
+
+	if ShouldLog(nil) {
+		t.Error("Expected nil not to be logged")
+	}
+}
@@ -0,0 +1,64 @@
+package errmgt
+
+import (
+	"log/slog"
+	"sort"
+)
+
+// LogValue implements slog.LogValuer so errors are logged as structured
+// groups rather than as a flat string.
+func (e *ManagedError) LogValue() slog.Value {
+	if e == nil {
+		return slog.StringValue(e.Error())
+	}
+	attrs := []slog.Attr{
+		slog.String("type", string(e.Type)),
+		slog.String("code", string(e.EffectiveCode())),
+		slog.String("message", e.GetMessage()),
+	}
+	if e.Details != "" {
+		attrs = append(attrs, slog.String("details", e.Details))
+	}
+	if e.StatusCode != 0 {
+		attrs = append(attrs, slog.Int("status_code", e.StatusCode))
+	}
+	attrs = append(attrs, slog.Bool("retryable", e.Retryable))
+	if e.Severity != 0 {
+		attrs = append(attrs, slog.String("severity", e.Severity.String()))
+	}
+	if len(e.Context) > 0 {
+		keys := make([]string, 0, len(e.Context))
+		for k := range e.Context {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		contextAttrs := make([]any, 0, len(keys))
+		for _, k := range keys {
+			contextAttrs = append(contextAttrs, slog.String(k, e.Context[k]))
+		}
+		attrs = append(attrs, slog.Group("context", contextAttrs...))
+	}
+	if len(e.Tags) > 0 {
+		attrs = append(attrs, slog.Any("tags", e.Tags))
+	}
+	if e.Component != "" {
+		attrs = append(attrs, slog.String("component", e.Component))
+	}
+	if e.ResourceType != "" || e.ResourceID != "" {
+		attrs = append(attrs, slog.Group("resource",
+			slog.String("type", e.ResourceType),
+			slog.String("id", e.ResourceID),
+		))
+	}
+	if e.Span != nil {
+		attrs = append(attrs, slog.Group("span",
+			slog.String("trace_id", e.Span.TraceID),
+			slog.String("span_id", e.Span.SpanID),
+		))
+	}
+	if e.Cause != nil {
+		attrs = append(attrs, slog.String("cause", e.Cause.Error()))
+	}
+	return slog.GroupValue(attrs...)
+}
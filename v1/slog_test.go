@@ -0,0 +1,43 @@
+package errmgt
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestManagedErrorLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	err := NewErrorWithCause(SystemError, "db_error", "Database error", errors.New("connection refused")).
+		WithDetails("primary unavailable").
+		WithContext("table", "users").
+		WithStatusCode(500).
+		WithSeverity(SeverityCritical).
+		WithSpanContext("trace", "span").
+		WithComponent("billing")
+	logger.Error("request failed", "error", err)
+
+	out := buf.String()
+	for _, want := range []string{
+		"error.type=system",
+		"error.code=db_error",
+		`error.message="Database error"`,
+		`error.details="primary unavailable"`,
+		"error.status_code=500",
+		"error.retryable=false",
+		"error.severity=critical",
+		"error.context.table=users",
+		"error.component=billing",
+		"error.span.trace_id=trace",
+		"error.span.span_id=span",
+		`error.cause="connection refused"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected log output to contain %q, got %s", want, out)
+		}
+	}
+}
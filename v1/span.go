@@ -0,0 +1,24 @@
+package errmgt
+
+// Span identifies the distributed tracing span an error occurred in
+type Span struct {
+	TraceID string `json:"trace_id"`
+	SpanID  string `json:"span_id"`
+}
+
+// WithSpanContext records the trace and span IDs the error occurred in
+func (e *ManagedError) WithSpanContext(traceID, spanID string) *ManagedError {
+	if e == nil {
+		return nil
+	}
+	e.Span = &Span{TraceID: traceID, SpanID: spanID}
+	return e
+}
+
+// SpanContext returns the trace and span IDs recorded on the error
+func (e *ManagedError) SpanContext() (traceID, spanID string, ok bool) {
+	if e == nil || e.Span == nil {
+		return "", "", false
+	}
+	return e.Span.TraceID, e.Span.SpanID, true
+}
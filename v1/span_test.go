@@ -0,0 +1,41 @@
+package errmgt
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestManagedErrorSpanContext(t *testing.T) {
+	err := NewError(SystemError, "db_error", "Database error")
+
+	if _, _, ok := err.SpanContext(); ok {
+		t.Error("Expected no span context on a new error")
+	}
+
+	err = err.WithSpanContext("4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7")
+	traceID, spanID, ok := err.SpanContext()
+	if !ok {
+		t.Fatal("Expected span context to be set")
+	}
+	if traceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Expected trace ID to be set, got '%s'", traceID)
+	}
+	if spanID != "00f067aa0ba902b7" {
+		t.Errorf("Expected span ID to be set, got '%s'", spanID)
+	}
+}
+
+func TestManagedErrorSpanJSON(t *testing.T) {
+	err := NewError(SystemError, "db_error", "Database error").
+		WithSpanContext("trace", "span")
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("Failed to marshal error: %v", marshalErr)
+	}
+
+	if !strings.Contains(string(data), `"span":{"trace_id":"trace","span_id":"span"}`) {
+		t.Errorf("Expected span object in JSON, got %s", data)
+	}
+}
@@ -0,0 +1,82 @@
+package errmgt
+
+import (
+	"runtime"
+	"sync/atomic"
+)
+
+// DefaultMaxStackDepth is the maximum number of frames captured per error
+// unless changed with SetMaxStackDepth
+const DefaultMaxStackDepth = 32
+
+var captureStack atomic.Bool
+
+var maxStackDepth atomic.Int64
+
+// SetCaptureStack enables or disables stack trace capture when errors are
+// constructed. Capture is disabled by default because it costs an
+// allocation and a runtime.Callers call per error.
+func SetCaptureStack(enabled bool) {
+	captureStack.Store(enabled)
+}
+
+// SetMaxStackDepth limits how many frames are captured per error when
+// stack capture is enabled, trading detail for capture cost and log size.
+// Frames beyond the limit, the outermost ones, are dropped. A value of
+// zero or less restores DefaultMaxStackDepth.
+func SetMaxStackDepth(n int) {
+	maxStackDepth.Store(int64(n))
+}
+
+// stackDepth returns the limit set with SetMaxStackDepth
+func stackDepth() int {
+	if n := int(maxStackDepth.Load()); n > 0 {
+		return n
+	}
+	return DefaultMaxStackDepth
+}
+
+// Frame is a single resolved stack frame
+type Frame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// StackTrace returns the stack captured when the error was constructed,
+// innermost frame first, with at most as many frames as were captured. It
+// returns nil when stack capture was disabled.
+func (e *ManagedError) StackTrace() []Frame {
+	if e == nil || len(e.stack) == 0 {
+		return nil
+	}
+	frames := runtime.CallersFrames(e.stack)
+	trace := make([]Frame, 0, len(e.stack))
+	for len(trace) < len(e.stack) {
+		frame, more := frames.Next()
+		trace = append(trace, Frame{Function: frame.Function, File: frame.File, Line: frame.Line})
+		if !more {
+			break
+		}
+	}
+	return trace
+}
+
+// NewErrorSkip creates a new ManagedError like NewError, skipping skip
+// additional frames when capturing the stack trace. The frames of the
+// errmgt constructors themselves are always skipped, so a skip of 0 places
+// the caller of NewErrorSkip at the top of the trace and a skip of 1 places
+// that caller's caller there. Helpers that wrap NewErrorSkip should pass
+// one more than the number of helper layers they want to hide.
+func NewErrorSkip(skip int, errType ErrorType, code Code, message string) *ManagedError {
+	return newError(skip+1, errType, code, message, nil)
+}
+
+// callers returns the program counters of the stack starting skip frames
+// above the caller of callers.
+func callers(skip int) []uintptr {
+	pcs := make([]uintptr, stackDepth())
+	// Skip runtime.Callers and callers itself
+	n := runtime.Callers(skip+2, pcs)
+	return pcs[:n]
+}
@@ -0,0 +1,106 @@
+package errmgt
+
+import (
+	"strings"
+	"testing"
+)
+
+func newHelperError() *ManagedError {
+	return NewErrorSkip(1, SystemError, "helper", "Created by helper")
+}
+
+func newNestedHelperError() *ManagedError {
+	return newNestedHelperErrorInner()
+}
+
+func newNestedHelperErrorInner() *ManagedError {
+	return NewErrorSkip(2, SystemError, "helper", "Created by nested helper")
+}
+
+func topFunction(t *testing.T, err *ManagedError) string {
+	t.Helper()
+	trace := err.StackTrace()
+	if len(trace) == 0 {
+		t.Fatal("Expected a stack trace to be captured")
+	}
+	return trace[0].Function
+}
+
+func TestStackTraceDisabledByDefault(t *testing.T) {
+	err := NewError(SystemError, "db_error", "Database error")
+
+	if trace := err.StackTrace(); trace != nil {
+		t.Errorf("Expected no stack trace, got %v", trace)
+	}
+}
+
+func TestStackTraceCapture(t *testing.T) {
+	SetCaptureStack(true)
+	defer SetCaptureStack(false)
+
+	tests := []struct {
+		name string
+		err  *ManagedError
+	}{
+		{name: "NewError", err: NewError(SystemError, "db_error", "Database error")},
+		{name: "NewErrorWithCause", err: NewErrorWithCause(SystemError, "db_error", "Database error", nil)},
+		{name: "NewErrorString", err: NewErrorString(SystemError, "db_error", "Database error")},
+		{name: "NewErrorSkip", err: NewErrorSkip(0, SystemError, "db_error", "Database error")},
+		{name: "helper", err: newHelperError()},
+		{name: "nested helper", err: newNestedHelperError()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if fn := topFunction(t, tt.err); !strings.HasSuffix(fn, ".TestStackTraceCapture") {
+				t.Errorf("Expected top frame to be the test function, got %s", fn)
+			}
+		})
+	}
+}
+
+func TestStackTraceFrames(t *testing.T) {
+	SetCaptureStack(true)
+	defer SetCaptureStack(false)
+
+	trace := NewError(SystemError, "db_error", "Database error").StackTrace()
+	if !strings.HasSuffix(trace[0].File, "stack_test.go") {
+		t.Errorf("Expected top frame file to be stack_test.go, got %s", trace[0].File)
+	}
+	if trace[0].Line == 0 {
+		t.Error("Expected top frame line to be set")
+	}
+}
+
+// deepError creates an error n calls below the caller
+func deepError(n int) *ManagedError {
+	if n == 0 {
+		return NewError(SystemError, "db_error", "Database error")
+	}
+	return deepError(n - 1)
+}
+
+func TestSetMaxStackDepth(t *testing.T) {
+	SetCaptureStack(true)
+	defer SetCaptureStack(false)
+
+	if trace := deepError(50).StackTrace(); len(trace) != DefaultMaxStackDepth {
+		t.Errorf("Expected %d frames by default, got %d", DefaultMaxStackDepth, len(trace))
+	}
+
+	SetMaxStackDepth(4)
+	defer SetMaxStackDepth(0)
+
+	trace := deepError(50).StackTrace()
+	if len(trace) != 4 {
+		t.Fatalf("Expected 4 frames, got %d", len(trace))
+	}
+	if !strings.HasSuffix(trace[0].Function, ".deepError") {
+		t.Errorf("Expected the innermost frames to be kept, got %s", trace[0].Function)
+	}
+
+	SetMaxStackDepth(100)
+	if trace := deepError(50).StackTrace(); len(trace) <= DefaultMaxStackDepth {
+		t.Errorf("Expected more than %d frames, got %d", DefaultMaxStackDepth, len(trace))
+	}
+}
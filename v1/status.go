@@ -0,0 +1,40 @@
+package errmgt
+
+import "net/http"
+
+// defaultStatusCodes maps each ErrorType to the HTTP status code used when
+// an error has no explicit StatusCode
+var defaultStatusCodes = map[ErrorType]int{
+	ValidationError: http.StatusBadRequest,
+	BusinessError:   http.StatusUnprocessableEntity,
+	SystemError:     http.StatusInternalServerError,
+	ExternalError:   http.StatusBadGateway,
+}
+
+// HTTPStatus returns the HTTP status code for the error. It is StatusCode
+// when set, otherwise the default for the error's Type: 400 for
+// validation, 422 for business, 500 for system and 502 for external
+// errors. Unknown types map to 500.
+func (e *ManagedError) HTTPStatus() int {
+	if e == nil {
+		return http.StatusInternalServerError
+	}
+	if e.StatusCode != 0 {
+		return e.StatusCode
+	}
+	if status, exists := defaultStatusCodes[e.Type]; exists {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// WithDefaultStatus stores the type-based default status code in
+// StatusCode when none is set, so serialized errors always carry an
+// explicit status. An existing StatusCode is left unchanged.
+func (e *ManagedError) WithDefaultStatus() *ManagedError {
+	if e == nil {
+		return nil
+	}
+	e.StatusCode = e.HTTPStatus()
+	return e
+}
@@ -0,0 +1,51 @@
+package errmgt
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestWithDefaultStatus(t *testing.T) {
+	tests := []struct {
+		errType  ErrorType
+		expected int
+	}{
+		{ValidationError, http.StatusBadRequest},
+		{BusinessError, http.StatusUnprocessableEntity},
+		{SystemError, http.StatusInternalServerError},
+		{ExternalError, http.StatusBadGateway},
+		{ErrorType("unknown"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.errType), func(t *testing.T) {
+			err := NewError(tt.errType, "code", "message")
+
+			if status := err.HTTPStatus(); status != tt.expected {
+				t.Errorf("Expected HTTPStatus %d, got %d", tt.expected, status)
+			}
+
+			if err.StatusCode != 0 {
+				t.Errorf("Expected HTTPStatus to leave StatusCode unset, got %d", err.StatusCode)
+			}
+
+			if err.WithDefaultStatus().StatusCode != tt.expected {
+				t.Errorf("Expected StatusCode %d, got %d", tt.expected, err.StatusCode)
+			}
+		})
+	}
+}
+
+func TestWithDefaultStatusKeepsExplicitStatus(t *testing.T) {
+	err := NewError(ValidationError, "conflict", "Conflict").
+		WithStatusCode(http.StatusConflict).
+		WithDefaultStatus()
+
+	if err.StatusCode != http.StatusConflict {
+		t.Errorf("Expected StatusCode %d, got %d", http.StatusConflict, err.StatusCode)
+	}
+
+	if err.HTTPStatus() != http.StatusConflict {
+		t.Errorf("Expected HTTPStatus %d, got %d", http.StatusConflict, err.HTTPStatus())
+	}
+}
@@ -0,0 +1,35 @@
+package errmgt
+
+// WithTags adds free-form labels to the error. Tags already present are
+// not added again, and insertion order is preserved.
+func (e *ManagedError) WithTags(tags ...string) *ManagedError {
+	if e == nil {
+		return nil
+	}
+	for _, tag := range tags {
+		if !e.hasTag(tag) {
+			e.Tags = append(e.Tags, tag)
+		}
+	}
+	return e
+}
+
+func (e *ManagedError) hasTag(tag string) bool {
+	if e == nil {
+		return false
+	}
+	for _, t := range e.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// HasTag checks if an error is tagged with tag
+func HasTag(err error, tag string) bool {
+	if managedErr, ok := asManaged(err); ok {
+		return managedErr.hasTag(tag)
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package errmgt
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestManagedErrorWithTags(t *testing.T) {
+	err := NewError(ValidationError, "invalid_card", "Invalid card").
+		WithTags("user_facing", "pci").
+		WithTags("pci", "billing")
+
+	expected := []string{"user_facing", "pci", "billing"}
+	if !reflect.DeepEqual(err.Tags, expected) {
+		t.Errorf("Tags = %v, want %v", err.Tags, expected)
+	}
+}
+
+func TestHasTag(t *testing.T) {
+	err := Wrap(NewError(ValidationError, "invalid_card", "Invalid card").WithTags("user_facing"), "charging")
+
+	if !HasTag(err, "user_facing") {
+		t.Error("Expected wrapped error to have the user_facing tag")
+	}
+
+	if HasTag(err, "pci") {
+		t.Error("Expected error not to have the pci tag")
+	}
+
+	if HasTag(errors.New("plain error"), "user_facing") {
+		t.Error("Expected plain error not to have tags")
+	}
+}
+
+func TestTagsJSONAndLogValue(t *testing.T) {
+	err := NewError(ValidationError, "invalid_card", "Invalid card").WithTags("user_facing", "pci")
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("Failed to marshal error: %v", marshalErr)
+	}
+	if !strings.Contains(string(data), `"tags":["user_facing","pci"]`) {
+		t.Errorf("Expected tags in JSON, got %s", data)
+	}
+
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Error("failed", "error", err)
+	if !strings.Contains(buf.String(), `error.tags="[user_facing pci]"`) {
+		t.Errorf("Expected tags in log output, got %s", buf.String())
+	}
+}
@@ -0,0 +1,35 @@
+package errmgt
+
+// Template is a reusable error definition. Unlike a package-level
+// *ManagedError sentinel, which is shared and therefore mutated by every
+// WithContext call made on it, a Template produces a fresh ManagedError on
+// each call to New, so callers can enrich the result freely:
+//
+//	var UserNotFound = errmgt.DefineTemplate(errmgt.BusinessError, "user_not_found", "User not found")
+//
+//	return UserNotFound.New("user_id", id)
+//
+// Errors created from the same Template match each other with errors.Is,
+// since ManagedError.Is compares type and code.
+type Template struct {
+	errType ErrorType
+	code    Code
+	message string
+}
+
+// DefineTemplate creates a Template for errors with the given type, code
+// and message
+func DefineTemplate(errType ErrorType, code Code, message string) *Template {
+	return &Template{errType: errType, code: code, message: message}
+}
+
+// New creates a ManagedError from the template. contextPairs are
+// alternating keys and values added to its context; a trailing key
+// without a value is ignored.
+func (t *Template) New(contextPairs ...string) *ManagedError {
+	e := newError(1, t.errType, t.code, t.message, nil)
+	for i := 0; i+1 < len(contextPairs); i += 2 {
+		e.WithContext(contextPairs[i], contextPairs[i+1])
+	}
+	return e
+}
@@ -0,0 +1,52 @@
+package errmgt
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTemplateNew(t *testing.T) {
+	userNotFound := DefineTemplate(BusinessError, "user_not_found", "User not found")
+
+	first := userNotFound.New("user_id", "42", "tenant", "acme")
+	second := userNotFound.New()
+
+	if first.Type != BusinessError || first.Code != "user_not_found" || first.Message != "User not found" {
+		t.Errorf("Expected template fields, got %v", first)
+	}
+
+	if first.Context["user_id"] != "42" || first.Context["tenant"] != "acme" {
+		t.Errorf("Expected context pairs to be added, got %v", first.Context)
+	}
+
+	if first == second {
+		t.Fatal("Expected each call to create a new error")
+	}
+
+	if second.Context != nil {
+		t.Errorf("Expected context of one error not to leak into another, got %v", second.Context)
+	}
+
+	if !errors.Is(first, second) {
+		t.Error("Expected errors from the same template to match")
+	}
+}
+
+func TestTemplateNewOddPairs(t *testing.T) {
+	err := DefineTemplate(ValidationError, "invalid_input", "Invalid input").New("field", "email", "dangling")
+
+	if len(err.Context) != 1 || err.Context["field"] != "email" {
+		t.Errorf("Expected trailing key to be ignored, got %v", err.Context)
+	}
+}
+
+func TestTemplateNewStack(t *testing.T) {
+	SetCaptureStack(true)
+	defer SetCaptureStack(false)
+
+	trace := DefineTemplate(SystemError, "db_error", "Database error").New().StackTrace()
+	if len(trace) == 0 || !strings.HasSuffix(trace[0].Function, "TestTemplateNewStack") {
+		t.Errorf("Expected the caller of New at the top of the stack, got %v", trace)
+	}
+}
@@ -0,0 +1,42 @@
+package errmgt
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// TimeoutCode is the error code used by Timeout
+const TimeoutCode Code = "timeout"
+
+// DefaultTimeoutRetryAfter is the RetryAfter value set by Timeout
+const DefaultTimeoutRetryAfter = time.Second
+
+// Timeout creates a retryable ExternalError describing an operation that
+// did not complete within d.
+func Timeout(operation string, d time.Duration) *ManagedError {
+	return newError(1, ExternalError, TimeoutCode, fmt.Sprintf("%s timed out after %s", operation, d), nil).
+		WithContext("operation", operation).
+		WithRetryable(true).
+		WithRetryAfter(DefaultTimeoutRetryAfter).
+		WithStatusCode(http.StatusGatewayTimeout)
+}
+
+// NewFromDeadline creates an error for an operation that failed because
+// ctx's deadline passed. It is a retryable ExternalError whose context
+// records the deadline under "deadline" and how long ago it passed under
+// "overrun"; GetContextTime and GetContextDuration read them back. When
+// ctx has no deadline, the error carries neither key and is not
+// retryable. ctx.Err(), if any, becomes the cause.
+func NewFromDeadline(ctx context.Context, code Code, message string) *ManagedError {
+	err := newError(1, ExternalError, code, message, ctx.Err())
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return err
+	}
+	return err.
+		WithContextTime("deadline", deadline).
+		WithContextDuration("overrun", currentTime().Sub(deadline)).
+		WithRetryable(true)
+}
@@ -0,0 +1,76 @@
+package errmgt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimeout(t *testing.T) {
+	err := Timeout("fetch user", 3*time.Second)
+
+	if err.Type != ExternalError {
+		t.Errorf("Expected type %s, got %s", ExternalError, err.Type)
+	}
+
+	if err.Code != TimeoutCode {
+		t.Errorf("Expected code '%s', got '%s'", TimeoutCode, err.Code)
+	}
+
+	if err.Message != "fetch user timed out after 3s" {
+		t.Errorf("Expected message 'fetch user timed out after 3s', got '%s'", err.Message)
+	}
+
+	if !IsRetryable(err) {
+		t.Error("Expected timeout error to be retryable")
+	}
+
+	if !err.Temporary() {
+		t.Error("Expected timeout error to be temporary")
+	}
+
+	if err.RetryAfter != DefaultTimeoutRetryAfter {
+		t.Errorf("Expected retry after %s, got %s", DefaultTimeoutRetryAfter, err.RetryAfter)
+	}
+
+	if err.Context["operation"] != "fetch user" {
+		t.Error("Expected operation context to be set")
+	}
+}
+
+func TestNewFromDeadline(t *testing.T) {
+	deadline := time.Now().Add(-50 * time.Millisecond)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	err := NewFromDeadline(ctx, "lookup_timeout", "Lookup timed out")
+
+	if err.Type != ExternalError || !err.Retryable {
+		t.Errorf("Expected a retryable external error, got %v", err)
+	}
+
+	if got, ok := GetContextTime(err, "deadline"); !ok || !got.Equal(deadline) {
+		t.Errorf("Expected deadline %v in context, got %v, %v", deadline, got, ok)
+	}
+
+	if overrun, ok := GetContextDuration(err, "overrun"); !ok || overrun < 50*time.Millisecond {
+		t.Errorf("Expected an overrun of at least 50ms, got %v, %v", overrun, ok)
+	}
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("Expected the context error to be the cause")
+	}
+}
+
+func TestNewFromDeadlineWithoutDeadline(t *testing.T) {
+	err := NewFromDeadline(context.Background(), "lookup_failed", "Lookup failed")
+
+	if err.Type != ExternalError || err.Code != "lookup_failed" {
+		t.Errorf("Expected an external error, got %v", err)
+	}
+
+	if err.Retryable || err.Context != nil || err.Cause != nil {
+		t.Errorf("Expected no timing diagnostics, got %+v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package errmgt
+
+// Transform remaps the type of the first ManagedError in err's chain
+// according to rules, for example to present several internal types as a
+// single public one at an API boundary. It returns a copy of that
+// ManagedError with the new type and every other field preserved; the
+// original is not modified. err is returned unchanged when it contains no
+// ManagedError or no rule matches its type.
+func Transform(err error, rules map[ErrorType]ErrorType) error {
+	managedErr, ok := asManaged(err)
+	if !ok {
+		return err
+	}
+	errType, exists := rules[managedErr.Type]
+	if !exists {
+		return err
+	}
+	transformed := managedErr.clone()
+	transformed.Type = errType
+	return transformed
+}
+
+// TransformCode remaps the code of the first ManagedError in err's chain
+// according to rules, in the same way Transform remaps types
+func TransformCode(err error, rules map[string]string) error {
+	managedErr, ok := asManaged(err)
+	if !ok {
+		return err
+	}
+	code, exists := rules[string(managedErr.Code)]
+	if !exists {
+		return err
+	}
+	transformed := managedErr.clone()
+	transformed.Code = Code(code)
+	return transformed
+}
+
+// clone returns a copy of the error that shares no maps or slices with it
+func (e *ManagedError) clone() *ManagedError {
+	e.GetMessage()
+	c := *e
+	if e.Context != nil {
+		c.Context = make(map[string]string, len(e.Context))
+		for k, v := range e.Context {
+			c.Context[k] = v
+		}
+	}
+	if e.publicContextKeys != nil {
+		c.publicContextKeys = make(map[string]bool, len(e.publicContextKeys))
+		for k := range e.publicContextKeys {
+			c.publicContextKeys[k] = true
+		}
+	}
+	c.Tags = append([]string(nil), e.Tags...)
+	c.Fingerprint = append([]string(nil), e.Fingerprint...)
+	c.stack = append([]uintptr(nil), e.stack...)
+	c.detailLines = append([]string(nil), e.detailLines...)
+	if e.Span != nil {
+		span := *e.Span
+		c.Span = &span
+	}
+	return &c
+}
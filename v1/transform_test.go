@@ -0,0 +1,74 @@
+package errmgt
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestTransform(t *testing.T) {
+	original := NewError(BusinessError, "user_not_found", "User not found").
+		WithContext("user_id", "42").
+		WithStatusCode(404)
+	rules := map[ErrorType]ErrorType{BusinessError: ValidationError}
+
+	err := Transform(fmt.Errorf("lookup: %w", original), rules)
+
+	var managedErr *ManagedError
+	if !errors.As(err, &managedErr) {
+		t.Fatalf("Expected a ManagedError, got %T", err)
+	}
+
+	if managedErr.Type != ValidationError {
+		t.Errorf("Expected type %s, got %s", ValidationError, managedErr.Type)
+	}
+
+	if managedErr.Code != "user_not_found" || managedErr.StatusCode != 404 || managedErr.Context["user_id"] != "42" {
+		t.Errorf("Expected other fields to be preserved, got %+v", managedErr)
+	}
+
+	if original.Type != BusinessError {
+		t.Error("Expected the original error to be unchanged")
+	}
+
+	managedErr.WithContext("extra", "value")
+	if _, exists := original.Context["extra"]; exists {
+		t.Error("Expected the transformed error not to share context with the original")
+	}
+}
+
+func TestTransformNoMatch(t *testing.T) {
+	original := NewError(SystemError, "db_error", "Database error")
+
+	if err := Transform(original, map[ErrorType]ErrorType{BusinessError: ValidationError}); err != original {
+		t.Errorf("Expected unmatched error to be returned unchanged, got %v", err)
+	}
+
+	plain := errors.New("plain")
+	if err := Transform(plain, map[ErrorType]ErrorType{SystemError: ExternalError}); err != plain {
+		t.Errorf("Expected plain error to pass through, got %v", err)
+	}
+
+	if err := Transform(nil, map[ErrorType]ErrorType{SystemError: ExternalError}); err != nil {
+		t.Errorf("Expected nil to pass through, got %v", err)
+	}
+}
+
+func TestTransformCode(t *testing.T) {
+	original := NewError(BusinessError, "account_missing", "Account missing")
+	rules := map[string]string{"account_missing": "not_found", "user_missing": "not_found"}
+
+	err := TransformCode(original, rules)
+
+	if !errors.Is(err, NewError(BusinessError, "not_found", "")) {
+		t.Errorf("Expected code to be remapped, got %v", err)
+	}
+
+	if original.Code != "account_missing" {
+		t.Error("Expected the original error to be unchanged")
+	}
+
+	if other := NewError(BusinessError, "other", "Other"); TransformCode(other, rules) != other {
+		t.Error("Expected unmatched code to be returned unchanged")
+	}
+}
@@ -0,0 +1,24 @@
+package errmgt
+
+// WithTransient sets whether the error is transient: the condition is
+// expected to clear on its own, as with a dependency running in degraded
+// mode. Transience is independent of Retryable, which says whether the
+// caller should try the operation again. A circuit breaker may tolerate
+// transient errors that a retry loop should still not repeat, and a
+// retryable error, such as a lost write conflict, need not be transient.
+func (e *ManagedError) WithTransient(transient bool) *ManagedError {
+	if e == nil {
+		return nil
+	}
+	e.Transient = transient
+	return e
+}
+
+// IsTransient checks if the first ManagedError in err's chain is marked
+// transient. It does not consider Retryable.
+func IsTransient(err error) bool {
+	if managedErr, ok := asManaged(err); ok {
+		return managedErr.Transient
+	}
+	return false
+}
@@ -0,0 +1,27 @@
+package errmgt
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithTransient(t *testing.T) {
+	err := NewError(ExternalError, "degraded_mode", "Search is running in degraded mode").WithTransient(true)
+
+	if !IsTransient(Wrap(err, "search")) {
+		t.Error("Expected the error to be transient")
+	}
+
+	if IsRetryable(err) {
+		t.Error("Expected transience not to imply retryability")
+	}
+
+	retryable := NewError(BusinessError, "write_conflict", "Write conflict").WithRetryable(true)
+	if IsTransient(retryable) {
+		t.Error("Expected retryability not to imply transience")
+	}
+
+	if IsTransient(errors.New("plain")) || IsTransient(nil) {
+		t.Error("Expected plain and nil errors not to be transient")
+	}
+}
@@ -0,0 +1,55 @@
+package errmgt
+
+import (
+	"sort"
+	"strings"
+)
+
+// FieldContextKey is the context key under which ValidationErrors records
+// the name of the invalid field
+const FieldContextKey = "field"
+
+// ValidationErrors builds one ValidationError per entry of fieldMessages,
+// which maps field names to messages, and returns them as a MultiError
+// ordered by field name. Each error's code is "invalid_" followed by the
+// field name in snake_case, and its context records the field under
+// FieldContextKey. It returns nil when fieldMessages is empty.
+func ValidationErrors(fieldMessages map[string]string) error {
+	if len(fieldMessages) == 0 {
+		return nil
+	}
+
+	fields := make([]string, 0, len(fieldMessages))
+	for field := range fieldMessages {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	errs := make([]error, len(fields))
+	for i, field := range fields {
+		errs[i] = newError(1, ValidationError, fieldCode(field), fieldMessages[field], nil).
+			WithContext(FieldContextKey, field)
+	}
+	return &MultiError{Errors: errs}
+}
+
+// fieldCode derives a validation error code from a field name, so that
+// "Email" becomes "invalid_email" and "address.zipCode" becomes
+// "invalid_address_zipcode"
+func fieldCode(field string) Code {
+	var b strings.Builder
+	b.WriteString("invalid")
+	separate := true
+	for _, r := range strings.ToLower(field) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if separate {
+				b.WriteByte('_')
+				separate = false
+			}
+			b.WriteRune(r)
+		} else {
+			separate = true
+		}
+	}
+	return Code(b.String())
+}
@@ -0,0 +1,79 @@
+package errmgt
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidationErrors(t *testing.T) {
+	err := ValidationErrors(map[string]string{
+		"name":  "Name is required",
+		"Email": "Email is invalid",
+	})
+
+	var multi *MultiError
+	if !errors.As(err, &multi) {
+		t.Fatalf("Expected a MultiError, got %T", err)
+	}
+
+	if len(multi.Errors) != 2 {
+		t.Fatalf("Expected 2 errors, got %d", len(multi.Errors))
+	}
+
+	expected := []struct {
+		code    Code
+		field   string
+		message string
+	}{
+		{"invalid_email", "Email", "Email is invalid"},
+		{"invalid_name", "name", "Name is required"},
+	}
+
+	for i, want := range expected {
+		var managedErr *ManagedError
+		if !errors.As(multi.Errors[i], &managedErr) {
+			t.Fatalf("Expected error %d to be a ManagedError, got %T", i, multi.Errors[i])
+		}
+
+		if managedErr.Type != ValidationError {
+			t.Errorf("Expected type %s, got %s", ValidationError, managedErr.Type)
+		}
+
+		if managedErr.Code != want.code {
+			t.Errorf("Expected code '%s', got '%s'", want.code, managedErr.Code)
+		}
+
+		if managedErr.Message != want.message {
+			t.Errorf("Expected message '%s', got '%s'", want.message, managedErr.Message)
+		}
+
+		if managedErr.Context[FieldContextKey] != want.field {
+			t.Errorf("Expected field '%s', got '%s'", want.field, managedErr.Context[FieldContextKey])
+		}
+	}
+
+	if !errors.Is(err, NewError(ValidationError, "invalid_name", "")) {
+		t.Error("Expected errors.Is to find an individual field error")
+	}
+}
+
+func TestValidationErrorsEmpty(t *testing.T) {
+	if err := ValidationErrors(nil); err != nil {
+		t.Errorf("Expected nil for no fields, got %v", err)
+	}
+}
+
+func TestFieldCode(t *testing.T) {
+	tests := map[string]Code{
+		"email":           "invalid_email",
+		"address.zipCode": "invalid_address_zipcode",
+		"items[0].name":   "invalid_items_0_name",
+		"-":               "invalid",
+	}
+
+	for field, expected := range tests {
+		if code := fieldCode(field); code != expected {
+			t.Errorf("fieldCode(%q) = %q, want %q", field, code, expected)
+		}
+	}
+}
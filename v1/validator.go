@@ -0,0 +1,77 @@
+package errmgt
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+)
+
+// RuleContextKey is the context key under which Validator records the
+// name of the rule a field failed, such as "required"
+const RuleContextKey = "rule"
+
+// Validator accumulates field validation rules and reports the failures as
+// ValidationErrors. The zero value is ready to use:
+//
+//	var v errmgt.Validator
+//	v.Required("email", req.Email).
+//		Matches("email", req.Email, emailPattern).
+//		MinLen("password", req.Password, 8)
+//	if err := v.Validate(); err != nil {
+//		return err
+//	}
+//
+// Each failure is a ValidationError with the same code ValidationErrors
+// would give its field, and context recording the field under
+// FieldContextKey and the rule under RuleContextKey. A Validator is not
+// safe for concurrent use.
+type Validator struct {
+	errs []error
+}
+
+// Required fails when value is empty
+func (v *Validator) Required(field, value string) *Validator {
+	if value == "" {
+		v.add(field, "required", fmt.Sprintf("%s is required", field))
+	}
+	return v
+}
+
+// MinLen fails when value has fewer than n characters. An empty value
+// fails too; combine with Required to report it as missing instead.
+func (v *Validator) MinLen(field, value string, n int) *Validator {
+	if utf8.RuneCountInString(value) < n {
+		v.add(field, "min_len", fmt.Sprintf("%s must be at least %d characters", field, n)).
+			WithContext("min_len", strconv.Itoa(n))
+	}
+	return v
+}
+
+// Matches fails when value does not match pattern
+func (v *Validator) Matches(field, value string, pattern *regexp.Regexp) *Validator {
+	if !pattern.MatchString(value) {
+		v.add(field, "matches", fmt.Sprintf("%s has an invalid format", field)).
+			WithContext("pattern", pattern.String())
+	}
+	return v
+}
+
+// add records a failed rule for field. It must be called directly by a
+// rule method so the stack trace starts at the rule's caller.
+func (v *Validator) add(field, rule, message string) *ManagedError {
+	err := newError(2, ValidationError, fieldCode(field), message, nil).
+		WithContext(FieldContextKey, field).
+		WithContext(RuleContextKey, rule)
+	v.errs = append(v.errs, err)
+	return err
+}
+
+// Validate returns the failures as a MultiError, in the order the rules
+// were checked, or nil when every rule passed
+func (v *Validator) Validate() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return &MultiError{Errors: append([]error(nil), v.errs...)}
+}
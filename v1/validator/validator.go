@@ -0,0 +1,53 @@
+// Package validator converts errors from github.com/go-playground/validator
+// into errmgt errors. It is a separate module, so only programs that import
+// it depend on go-playground/validator.
+package validator
+
+import (
+	"errors"
+	"fmt"
+
+	playground "github.com/go-playground/validator/v10"
+	"github.com/kerzzt/go-errmgt"
+)
+
+const (
+	// TagContextKey is the context key under which FromValidationErrors
+	// records the validation tag that failed, such as "required"
+	TagContextKey = "tag"
+	// ParamContextKey is the context key under which FromValidationErrors
+	// records the tag's parameter, such as "8" for "min=8"
+	ParamContextKey = "param"
+)
+
+// FromValidationErrors converts the validator.ValidationErrors in err's
+// chain into a MultiError with one ValidationError per field error, in
+// the order the validator reported them. Each error has the code
+// "validation.<field>.<tag>", wraps its validator.FieldError as the cause
+// and records the field under errmgt.FieldContextKey, the tag under
+// TagContextKey and any parameter under ParamContextKey. The field name is
+// the one reported by the validator, so a tag name function registered
+// with RegisterTagNameFunc also applies to the codes.
+//
+// It returns nil for nil, and err unchanged when it holds no
+// ValidationErrors, such as a validator.InvalidValidationError.
+func FromValidationErrors(err error) error {
+	var validationErrs playground.ValidationErrors
+	if !errors.As(err, &validationErrs) || len(validationErrs) == 0 {
+		return err
+	}
+
+	errs := make([]error, len(validationErrs))
+	for i, fieldErr := range validationErrs {
+		code := errmgt.Code(fmt.Sprintf("validation.%s.%s", fieldErr.Field(), fieldErr.Tag()))
+		managedErr := errmgt.NewErrorWithCause(errmgt.ValidationError, code,
+			fmt.Sprintf("%s failed the '%s' validation", fieldErr.Field(), fieldErr.Tag()), fieldErr).
+			WithContext(errmgt.FieldContextKey, fieldErr.Field()).
+			WithContext(TagContextKey, fieldErr.Tag())
+		if param := fieldErr.Param(); param != "" {
+			managedErr.WithContext(ParamContextKey, param)
+		}
+		errs[i] = managedErr
+	}
+	return &errmgt.MultiError{Errors: errs}
+}
@@ -0,0 +1,90 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	playground "github.com/go-playground/validator/v10"
+	"github.com/kerzzt/go-errmgt"
+)
+
+type signup struct {
+	Email    string `validate:"required,email"`
+	Password string `validate:"min=8"`
+	Age      int    `validate:"gte=18"`
+}
+
+func TestFromValidationErrors(t *testing.T) {
+	validate := playground.New()
+	validationErr := validate.Struct(signup{Email: "", Password: "short", Age: 30})
+
+	err := FromValidationErrors(fmt.Errorf("signup: %w", validationErr))
+
+	var multi *errmgt.MultiError
+	if !errors.As(err, &multi) {
+		t.Fatalf("Expected a MultiError, got %T", err)
+	}
+
+	expected := []struct {
+		code  errmgt.Code
+		field string
+		tag   string
+		param string
+	}{
+		{"validation.Email.required", "Email", "required", ""},
+		{"validation.Password.min", "Password", "min", "8"},
+	}
+
+	if len(multi.Errors) != len(expected) {
+		t.Fatalf("Expected %d errors, got %d: %v", len(expected), len(multi.Errors), multi.Errors)
+	}
+
+	for i, want := range expected {
+		var managedErr *errmgt.ManagedError
+		if !errors.As(multi.Errors[i], &managedErr) {
+			t.Fatalf("Expected error %d to be a ManagedError, got %T", i, multi.Errors[i])
+		}
+
+		if managedErr.Type != errmgt.ValidationError {
+			t.Errorf("Expected type %s, got %s", errmgt.ValidationError, managedErr.Type)
+		}
+
+		if managedErr.Code != want.code {
+			t.Errorf("Expected code '%s', got '%s'", want.code, managedErr.Code)
+		}
+
+		if managedErr.Context[errmgt.FieldContextKey] != want.field {
+			t.Errorf("Expected field '%s', got '%s'", want.field, managedErr.Context[errmgt.FieldContextKey])
+		}
+
+		if managedErr.Context[TagContextKey] != want.tag {
+			t.Errorf("Expected tag '%s', got '%s'", want.tag, managedErr.Context[TagContextKey])
+		}
+
+		if param, ok := managedErr.Context[ParamContextKey]; param != want.param || ok != (want.param != "") {
+			t.Errorf("Expected param '%s', got '%s'", want.param, param)
+		}
+
+		var fieldErr playground.FieldError
+		if !errors.As(managedErr, &fieldErr) {
+			t.Error("Expected the FieldError to be kept as the cause")
+		}
+	}
+}
+
+func TestFromValidationErrorsPassthrough(t *testing.T) {
+	if err := FromValidationErrors(nil); err != nil {
+		t.Errorf("Expected nil for nil, got %v", err)
+	}
+
+	plain := errors.New("plain")
+	if err := FromValidationErrors(plain); err != plain {
+		t.Errorf("Expected other errors to be returned unchanged, got %v", err)
+	}
+
+	invalid := playground.New().Struct(nil)
+	if err := FromValidationErrors(invalid); err != invalid {
+		t.Errorf("Expected InvalidValidationError to be returned unchanged, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package errmgt
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+)
+
+func TestValidator(t *testing.T) {
+	var v Validator
+	v.Required("name", "").
+		Required("email", "alice@example").
+		Matches("email", "alice@example", regexp.MustCompile(`^[^@]+@[^@]+\.[a-z]+$`)).
+		MinLen("password", "short", 8).
+		MinLen("username", "alice", 3)
+
+	err := v.Validate()
+
+	var multi *MultiError
+	if !errors.As(err, &multi) {
+		t.Fatalf("Expected a MultiError, got %T", err)
+	}
+
+	expected := []struct {
+		code    Code
+		field   string
+		rule    string
+		message string
+	}{
+		{"invalid_name", "name", "required", "name is required"},
+		{"invalid_email", "email", "matches", "email has an invalid format"},
+		{"invalid_password", "password", "min_len", "password must be at least 8 characters"},
+	}
+
+	if len(multi.Errors) != len(expected) {
+		t.Fatalf("Expected %d errors, got %d: %v", len(expected), len(multi.Errors), multi.Errors)
+	}
+
+	for i, want := range expected {
+		var managedErr *ManagedError
+		if !errors.As(multi.Errors[i], &managedErr) {
+			t.Fatalf("Expected error %d to be a ManagedError, got %T", i, multi.Errors[i])
+		}
+
+		if managedErr.Type != ValidationError {
+			t.Errorf("Expected type %s, got %s", ValidationError, managedErr.Type)
+		}
+
+		if managedErr.Code != want.code {
+			t.Errorf("Expected code '%s', got '%s'", want.code, managedErr.Code)
+		}
+
+		if managedErr.Message != want.message {
+			t.Errorf("Expected message '%s', got '%s'", want.message, managedErr.Message)
+		}
+
+		if managedErr.Context[FieldContextKey] != want.field {
+			t.Errorf("Expected field '%s', got '%s'", want.field, managedErr.Context[FieldContextKey])
+		}
+
+		if managedErr.Context[RuleContextKey] != want.rule {
+			t.Errorf("Expected rule '%s', got '%s'", want.rule, managedErr.Context[RuleContextKey])
+		}
+	}
+
+	if min := multi.Errors[2].(*ManagedError).Context["min_len"]; min != "8" {
+		t.Errorf("Expected min_len context '8', got '%s'", min)
+	}
+
+	if !errors.Is(err, NewError(ValidationError, "invalid_password", "")) {
+		t.Error("Expected errors.Is to find an individual field error")
+	}
+}
+
+func TestValidatorPasses(t *testing.T) {
+	var v Validator
+	v.Required("name", "Alice").
+		MinLen("name", "Alice", 3).
+		Matches("name", "Alice", regexp.MustCompile(`^[A-Z]`))
+
+	if err := v.Validate(); err != nil {
+		t.Errorf("Expected nil when all rules pass, got %v", err)
+	}
+}
+
+func TestValidatorMinLenCountsCharacters(t *testing.T) {
+	var v Validator
+	v.MinLen("name", "José", 4)
+
+	if err := v.Validate(); err != nil {
+		t.Errorf("Expected MinLen to count characters rather than bytes, got %v", err)
+	}
+}
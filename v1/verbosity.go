@@ -0,0 +1,100 @@
+package errmgt
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Verbosity controls how much Error() renders
+type Verbosity int32
+
+const (
+	// Terse renders only the type, code, message and details
+	Terse Verbosity = iota
+	// Verbose additionally renders the context and the cause
+	Verbose
+)
+
+// VerbosityEnvVar is the environment variable read the first time an error
+// is rendered, unless SetVerbosity was called before. A true value as
+// accepted by strconv.ParseBool, such as "1" or "true", selects Verbose.
+const VerbosityEnvVar = "ERRMGT_VERBOSE"
+
+var (
+	verbosity     atomic.Int32
+	verbosityOnce sync.Once
+)
+
+// SetVerbosity sets how much Error() renders for every ManagedError. The
+// default is Terse, or Verbose when VerbosityEnvVar is set to a true
+// value. Calling SetVerbosity overrides the environment variable.
+func SetVerbosity(v Verbosity) {
+	verbosityOnce.Do(func() {})
+	verbosity.Store(int32(v))
+}
+
+// currentVerbosity returns the configured verbosity, reading
+// VerbosityEnvVar on first use
+func currentVerbosity() Verbosity {
+	verbosityOnce.Do(func() {
+		if enabled, err := strconv.ParseBool(os.Getenv(VerbosityEnvVar)); err == nil && enabled {
+			verbosity.Store(int32(Verbose))
+		}
+	})
+	return Verbosity(verbosity.Load())
+}
+
+// verboseError renders the Verbose form of Error(): the terse form
+// followed by the sorted context in braces and the cause, whose
+// ManagedErrors are rendered the same way up to SetMaxChainDisplay
+func (e *ManagedError) verboseError() string {
+	var b strings.Builder
+	e.writeVerboseError(&b)
+
+	limit := int(maxChainDisplay.Load())
+	cause := e.Cause
+	for level := 0; cause != nil; level++ {
+		if limit > 0 && level >= limit {
+			fmt.Fprintf(&b, ": ... (%d more)", Depth(cause)+1)
+			break
+		}
+		b.WriteString(": ")
+		next, ok := cause.(*ManagedError)
+		if !ok || next == nil {
+			b.WriteString(cause.Error())
+			break
+		}
+		next.writeVerboseError(&b)
+		cause = next.Cause
+	}
+	return b.String()
+}
+
+// writeVerboseError writes the Verbose form of the error alone, without
+// its causes
+func (e *ManagedError) writeVerboseError(b *strings.Builder) {
+	b.WriteString(e.terseError())
+	if len(e.Context) > 0 {
+		keys := make([]string, 0, len(e.Context))
+		for k := range e.Context {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		b.WriteString(" {")
+		for i, k := range keys {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteString(k)
+			b.WriteByte('=')
+			b.WriteString(e.Context[k])
+		}
+		b.WriteByte('}')
+	}
+}
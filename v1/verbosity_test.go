@@ -0,0 +1,100 @@
+package errmgt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// resetVerbosity restores the default verbosity and lets the environment
+// variable be read again
+func resetVerbosity() {
+	verbosityOnce = sync.Once{}
+	verbosity.Store(int32(Terse))
+}
+
+// pinVerbosity sets the verbosity for the rest of the test regardless of
+// VerbosityEnvVar, and restores the default when the test ends
+func pinVerbosity(t *testing.T, v Verbosity) {
+	t.Helper()
+	SetVerbosity(v)
+	t.Cleanup(resetVerbosity)
+}
+
+func TestSetVerbosity(t *testing.T) {
+	pinVerbosity(t, Terse)
+
+	err := NewErrorWithCause(SystemError, "db_error", "Database error", errors.New("connection refused")).
+		WithDetails("primary unavailable").
+		WithContext("table", "users").
+		WithContext("op", "insert")
+
+	terse := "[system:db_error] Database error: primary unavailable"
+	if got := err.Error(); got != terse {
+		t.Errorf("Error() = %q, want %q", got, terse)
+	}
+
+	SetVerbosity(Verbose)
+	expected := terse + " {op=insert table=users}: connection refused"
+	if got := err.Error(); got != expected {
+		t.Errorf("Error() = %q, want %q", got, expected)
+	}
+
+	if got := fmt.Sprintf("%+v", err); strings.Count(got, "connection refused") != 1 {
+		t.Errorf("Expected %%+v not to repeat the cause, got\n%s", got)
+	}
+
+	SetVerbosity(Terse)
+	if got := err.Error(); got != terse {
+		t.Errorf("Error() = %q, want %q", got, terse)
+	}
+}
+
+func TestVerbosityFromEnv(t *testing.T) {
+	defer resetVerbosity()
+
+	err := NewError(ValidationError, "invalid_email", "Invalid email").WithContext("field", "email")
+
+	resetVerbosity()
+	t.Setenv(VerbosityEnvVar, "true")
+	if got := err.Error(); got != "[validation:invalid_email] Invalid email {field=email}" {
+		t.Errorf("Expected verbose output from the environment, got %q", got)
+	}
+
+	resetVerbosity()
+	t.Setenv(VerbosityEnvVar, "1")
+	SetVerbosity(Terse)
+	if got := err.Error(); got != "[validation:invalid_email] Invalid email" {
+		t.Errorf("Expected SetVerbosity to override the environment, got %q", got)
+	}
+
+	resetVerbosity()
+	t.Setenv(VerbosityEnvVar, "no")
+	if got := err.Error(); got != "[validation:invalid_email] Invalid email" {
+		t.Errorf("Expected terse output, got %q", got)
+	}
+}
+
+func TestVerboseErrorMaxChainDisplay(t *testing.T) {
+	defer resetVerbosity()
+	defer SetMaxChainDisplay(0)
+
+	err := NewErrorWithCause(SystemError, "save_failed", "Save failed",
+		NewErrorWithCause(SystemError, "db_error", "Database error",
+			NewErrorWithCause(SystemError, "dial_failed", "Dial failed", errors.New("connection refused"))).
+			WithContext("table", "users"))
+
+	SetVerbosity(Verbose)
+	SetMaxChainDisplay(1)
+	expected := "[system:save_failed] Save failed: [system:db_error] Database error {table=users}: ... (2 more)"
+	if got := err.Error(); got != expected {
+		t.Errorf("Error() = %q, want %q", got, expected)
+	}
+
+	SetMaxChainDisplay(0)
+	if got := err.Error(); !strings.HasSuffix(got, "[system:dial_failed] Dial failed: connection refused") {
+		t.Errorf("Expected the whole chain without a limit, got %q", got)
+	}
+}